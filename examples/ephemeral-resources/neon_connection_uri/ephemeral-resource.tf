@@ -0,0 +1,5 @@
+ephemeral "neon_connection_uri" "example" {
+  project_id    = neon_project.example.id
+  database_name = neon_database.example.name
+  role_name     = neon_role.example.name
+}
@@ -0,0 +1,7 @@
+resource "neon_branch_restore" "example" {
+  branch_id           = neon_branch.example.id
+  project_id          = neon_project.example.id
+  source_branch_id    = neon_branch.example.id
+  source_timestamp    = "2024-01-01T00:00:00Z"
+  preserve_under_name = "analytics-before-restore"
+}
@@ -0,0 +1,10 @@
+resource "neon_default_privileges" "example" {
+  owner         = neon_database.example.owner_name
+  grantee       = neon_role.reporting.name
+  object_type   = "table"
+  schema        = "public"
+  privileges    = ["SELECT"]
+  database_name = neon_database.example.name
+  branch_id     = neon_project.example.branch.id
+  project_id    = neon_project.example.id
+}
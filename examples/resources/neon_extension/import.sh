@@ -0,0 +1 @@
+terraform import neon_extension.example silent-wood-306223:br-mute-rain-788791:neondb:vector
@@ -0,0 +1,10 @@
+resource "neon_grant" "example" {
+  grantee       = neon_role.reporting.name
+  object_type   = "table"
+  schema        = "public"
+  privileges    = ["SELECT"]
+  database_name = neon_database.example.name
+  role_name     = neon_database.example.owner_name
+  branch_id     = neon_project.example.branch.id
+  project_id    = neon_project.example.id
+}
@@ -0,0 +1,7 @@
+resource "neon_publication" "example" {
+  name          = "warehouse"
+  tables        = ["public.orders", "public.customers"]
+  database_name = neon_database.example.name
+  branch_id     = neon_project.example.branch.id
+  project_id    = neon_project.example.id
+}
@@ -0,0 +1 @@
+terraform import neon_replication_slot.example silent-wood-306223:br-mute-rain-788791:neondb:warehouse
@@ -0,0 +1,6 @@
+resource "neon_replication_slot" "example" {
+  name          = "warehouse"
+  database_name = neon_database.example.name
+  branch_id     = neon_project.example.branch.id
+  project_id    = neon_project.example.id
+}
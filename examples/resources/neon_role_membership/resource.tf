@@ -0,0 +1,7 @@
+resource "neon_role_membership" "example" {
+  group_role    = neon_role.app_ro.name
+  member_role   = neon_role.app_rw.name
+  database_name = neon_database.example.name
+  branch_id     = neon_project.example.branch.id
+  project_id    = neon_project.example.id
+}
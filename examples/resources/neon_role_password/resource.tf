@@ -0,0 +1,9 @@
+resource "neon_role_password" "example" {
+  role_name  = neon_role.example.name
+  branch_id  = neon_project.example.branch.id
+  project_id = neon_project.example.id
+
+  keepers = {
+    rotation = "2024-01"
+  }
+}
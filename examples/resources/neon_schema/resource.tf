@@ -0,0 +1,7 @@
+resource "neon_schema" "example" {
+  name          = "tenant_acme"
+  owner         = neon_role.example.name
+  database_name = neon_database.example.name
+  branch_id     = neon_project.example.branch.id
+  project_id    = neon_project.example.id
+}
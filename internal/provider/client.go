@@ -3,6 +3,7 @@ package provider
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,6 +26,22 @@ func (t *authedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return t.wrapped.RoundTrip(req)
 }
 
+// responseError is returned for responses with an error status, its message is the response body.
+type responseError struct {
+	status int
+	body   string
+}
+
+func (e *responseError) Error() string {
+	return e.body
+}
+
+func isNotFound(err error) bool {
+	var response *responseError
+
+	return errors.As(err, &response) && response.status == http.StatusNotFound
+}
+
 func delete(client *http.Client, url string) ([]byte, error) {
 	req, err := http.NewRequest(http.MethodDelete, url, nil)
 
@@ -51,7 +68,7 @@ func do(client *http.Client, req *http.Request, e error) ([]byte, error) {
 	}
 
 	if res.StatusCode >= 400 {
-		return nil, fmt.Errorf(string(responseBody))
+		return nil, &responseError{status: res.StatusCode, body: string(responseBody)}
 	}
 
 	return responseBody, nil
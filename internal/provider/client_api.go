@@ -1,13 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"time"
-
-	"golang.org/x/exp/slices"
 )
 
 func projectWait(client *http.Client, projectId string) error {
@@ -36,6 +36,34 @@ func branchList(client *http.Client, projectId string) (BranchListOutput, error)
 	return branches, err
 }
 
+func branchByName(client *http.Client, projectId string, name string) (Branch, error) {
+	var branch Branch
+
+	branches, err := branchList(client, projectId)
+
+	if err != nil {
+		return branch, err
+	}
+
+	branchIdx := slices.IndexFunc(branches.Branches, func(branch Branch) bool {
+		return branch.Name == name
+	})
+
+	if branchIdx == -1 {
+		return branch, fmt.Errorf("no branch named %s found in project %s", name, projectId)
+	}
+
+	return branches.Branches[branchIdx], nil
+}
+
+func projectGet(client *http.Client, projectId string) (ProjectOutput, error) {
+	var project ProjectOutput
+
+	err := get(client, fmt.Sprintf("/projects/%s", projectId), &project)
+
+	return project, err
+}
+
 func branchEndpoint(client *http.Client, projectId string, branchId string, throw bool) (Endpoint, error) {
 	endpoints, err := branchEndpointList(client, projectId, branchId)
 
@@ -76,6 +104,26 @@ func branchGet(client *http.Client, projectId string, branchId string) (BranchOu
 	return branch, nil
 }
 
+func branchWaitReady(ctx context.Context, client *http.Client, projectId string, branchId string) (BranchOutput, error) {
+	for {
+		branch, err := branchGet(client, projectId, branchId)
+
+		if err != nil {
+			return branch, err
+		}
+
+		if branch.Branch.CurrentState == "ready" {
+			return branch, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return branch, fmt.Errorf("branch %s is still %s: %s", branchId, branch.Branch.CurrentState, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
 func branchCreate(client *http.Client, projectId string, input BranchCreateInput) (BranchOutput, error) {
 	var branch BranchOutput
 
@@ -104,6 +152,20 @@ func branchUpdate(client *http.Client, projectId string, branchId string, input
 	return branch, err
 }
 
+func branchSetAsDefault(client *http.Client, projectId string, branchId string) (BranchOutput, error) {
+	var branch BranchOutput
+
+	err := projectWait(client, projectId)
+
+	if err != nil {
+		return branch, err
+	}
+
+	err = call(client, http.MethodPost, fmt.Sprintf("/projects/%s/branches/%s/set_as_default", projectId, branchId), struct{}{}, &branch)
+
+	return branch, err
+}
+
 func branchDelete(client *http.Client, projectId string, branchId string) error {
 	err := projectWait(client, projectId)
 
@@ -116,6 +178,20 @@ func branchDelete(client *http.Client, projectId string, branchId string) error
 	return err
 }
 
+func branchRestore(client *http.Client, projectId string, branchId string, input BranchRestoreInput) (BranchOutput, error) {
+	var branch BranchOutput
+
+	err := projectWait(client, projectId)
+
+	if err != nil {
+		return branch, err
+	}
+
+	err = call(client, http.MethodPost, fmt.Sprintf("/projects/%s/branches/%s/restore", projectId, branchId), input, &branch)
+
+	return branch, err
+}
+
 func branchEndpointList(client *http.Client, projectId string, branchId string) (BranchEndpointListOutput, error) {
 	var endpoints BranchEndpointListOutput
 
@@ -124,6 +200,34 @@ func branchEndpointList(client *http.Client, projectId string, branchId string)
 	return endpoints, err
 }
 
+func endpointGet(client *http.Client, projectId string, endpointId string) (EndpointOutput, error) {
+	var endpoint EndpointOutput
+
+	err := get(client, fmt.Sprintf("/projects/%s/endpoints/%s", projectId, endpointId), &endpoint)
+
+	return endpoint, err
+}
+
+func endpointWaitReady(ctx context.Context, client *http.Client, projectId string, endpointId string) (EndpointOutput, error) {
+	for {
+		endpoint, err := endpointGet(client, projectId, endpointId)
+
+		if err != nil {
+			return endpoint, err
+		}
+
+		if endpoint.Endpoint.CurrentState == "active" || endpoint.Endpoint.CurrentState == "idle" {
+			return endpoint, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return endpoint, fmt.Errorf("endpoint %s is still %s: %s", endpointId, endpoint.Endpoint.CurrentState, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
 func endpointCreate(client *http.Client, projectId string, input EndpointCreateInput) (EndpointOutput, error) {
 	var endpoint EndpointOutput
 
@@ -152,6 +256,34 @@ func endpointUpdate(client *http.Client, projectId string, endpointId string, in
 	return endpoint, err
 }
 
+func endpointStart(client *http.Client, projectId string, endpointId string) (EndpointOutput, error) {
+	var endpoint EndpointOutput
+
+	err := projectWait(client, projectId)
+
+	if err != nil {
+		return endpoint, err
+	}
+
+	err = call(client, http.MethodPost, fmt.Sprintf("/projects/%s/endpoints/%s/start", projectId, endpointId), struct{}{}, &endpoint)
+
+	return endpoint, err
+}
+
+func endpointSuspend(client *http.Client, projectId string, endpointId string) (EndpointOutput, error) {
+	var endpoint EndpointOutput
+
+	err := projectWait(client, projectId)
+
+	if err != nil {
+		return endpoint, err
+	}
+
+	err = call(client, http.MethodPost, fmt.Sprintf("/projects/%s/endpoints/%s/suspend", projectId, endpointId), struct{}{}, &endpoint)
+
+	return endpoint, err
+}
+
 func endpointDelete(client *http.Client, projectId string, endpointId string) error {
 	err := projectWait(client, projectId)
 
@@ -164,6 +296,47 @@ func endpointDelete(client *http.Client, projectId string, endpointId string) er
 	return err
 }
 
+func databaseList(client *http.Client, projectId string, branchId string) (DatabaseListOutput, error) {
+	var databases DatabaseListOutput
+
+	err := get(client, fmt.Sprintf("/projects/%s/branches/%s/databases", projectId, branchId), &databases)
+
+	return databases, err
+}
+
+func databaseGet(client *http.Client, projectId string, branchId string, name string) (DatabaseOutput, error) {
+	var database DatabaseOutput
+
+	err := get(client, fmt.Sprintf("/projects/%s/branches/%s/databases/%s", projectId, branchId, name), &database)
+
+	return database, err
+}
+
+// databaseWaitVisible waits until a database created over SQL shows up in the API.
+func databaseWaitVisible(ctx context.Context, client *http.Client, projectId string, branchId string, name string) (DatabaseOutput, error) {
+	for {
+		databases, err := databaseList(client, projectId, branchId)
+
+		if err != nil {
+			return DatabaseOutput{}, err
+		}
+
+		databaseIdx := slices.IndexFunc(databases.Databases, func(database Database) bool {
+			return database.Name == name
+		})
+
+		if databaseIdx != -1 {
+			return DatabaseOutput{Database: databases.Databases[databaseIdx]}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return DatabaseOutput{}, fmt.Errorf("database %s is not visible in branch %s: %s", name, branchId, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
 func databaseCreate(client *http.Client, projectId string, branchId string, input DatabaseCreateInput) (DatabaseOutput, error) {
 	var database DatabaseOutput
 
@@ -204,6 +377,55 @@ func databaseDelete(client *http.Client, projectId string, branchId string, name
 	return err
 }
 
+func roleList(client *http.Client, projectId string, branchId string) (RoleListOutput, error) {
+	var roles RoleListOutput
+
+	err := get(client, fmt.Sprintf("/projects/%s/branches/%s/roles", projectId, branchId), &roles)
+
+	return roles, err
+}
+
+func roleGet(client *http.Client, projectId string, branchId string, name string) (RoleOutput, error) {
+	var role RoleOutput
+
+	err := get(client, fmt.Sprintf("/projects/%s/branches/%s/roles/%s", projectId, branchId, name), &role)
+
+	return role, err
+}
+
+// roleWaitVisible waits until a role renamed over SQL shows up in the API.
+func roleWaitVisible(ctx context.Context, client *http.Client, projectId string, branchId string, name string) (RoleOutput, error) {
+	for {
+		roles, err := roleList(client, projectId, branchId)
+
+		if err != nil {
+			return RoleOutput{}, err
+		}
+
+		roleIdx := slices.IndexFunc(roles.Roles, func(role Role) bool {
+			return role.Name == name
+		})
+
+		if roleIdx != -1 {
+			return RoleOutput{Role: roles.Roles[roleIdx]}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return RoleOutput{}, fmt.Errorf("role %s is not visible in branch %s: %s", name, branchId, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func roleRevealPassword(client *http.Client, projectId string, branchId string, name string) (RolePasswordOutput, error) {
+	var password RolePasswordOutput
+
+	err := get(client, fmt.Sprintf("/projects/%s/branches/%s/roles/%s/reveal_password", projectId, branchId, name), &password)
+
+	return password, err
+}
+
 func roleCreate(client *http.Client, projectId string, branchId string, input RoleCreateInput) (RoleOutput, error) {
 	var role RoleOutput
 
@@ -218,6 +440,20 @@ func roleCreate(client *http.Client, projectId string, branchId string, input Ro
 	return role, err
 }
 
+func roleResetPassword(client *http.Client, projectId string, branchId string, name string) (RoleOutput, error) {
+	var role RoleOutput
+
+	err := projectWait(client, projectId)
+
+	if err != nil {
+		return role, err
+	}
+
+	err = call(client, http.MethodPost, fmt.Sprintf("/projects/%s/branches/%s/roles/%s/reset_password", projectId, branchId, name), struct{}{}, &role)
+
+	return role, err
+}
+
 func roleDelete(client *http.Client, projectId string, branchId string, name string) error {
 	err := projectWait(client, projectId)
 
@@ -20,6 +20,10 @@ type Branch struct {
 	Default      bool    `json:"default"`
 	Protected    bool    `json:"protected"`
 	CurrentState string  `json:"current_state"`
+	LogicalSize  int64   `json:"logical_size"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+	LastResetAt  *string `json:"last_reset_at"`
 }
 
 type Role struct {
@@ -37,17 +41,28 @@ type Database struct {
 }
 
 type Endpoint struct {
-	Id                    string  `json:"id"`
-	Host                  string  `json:"host"`
-	BranchId              string  `json:"branch_id"`
-	ProjectId             string  `json:"project_id"`
-	RegionId              string  `json:"region_id"`
-	AutoscalingLimitMinCu float64 `json:"autoscaling_limit_min_cu"`
-	AutoscalingLimitMaxCu float64 `json:"autoscaling_limit_max_cu"`
-	ComputeProvisioner    string  `json:"provisioner"`
-	SuspendTimeoutSeconds int64   `json:"suspend_timeout_seconds"`
-	Type                  string  `json:"type"`
-	CurrentState          string  `json:"current_state"`
+	Id                    string           `json:"id"`
+	Host                  string           `json:"host"`
+	BranchId              string           `json:"branch_id"`
+	ProjectId             string           `json:"project_id"`
+	RegionId              string           `json:"region_id"`
+	AutoscalingLimitMinCu float64          `json:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu float64          `json:"autoscaling_limit_max_cu"`
+	ComputeProvisioner    string           `json:"provisioner"`
+	SuspendTimeoutSeconds int64            `json:"suspend_timeout_seconds"`
+	Type                  string           `json:"type"`
+	CurrentState          string           `json:"current_state"`
+	Settings              EndpointSettings `json:"settings"`
+	PoolerEnabled         bool             `json:"pooler_enabled"`
+	PoolerMode            string           `json:"pooler_mode"`
+	Disabled              bool             `json:"disabled"`
+	ProxyHost             string           `json:"proxy_host"`
+	CreatedAt             string           `json:"created_at"`
+	LastActive            *string          `json:"last_active"`
+}
+
+type EndpointSettings struct {
+	PgSettings map[string]string `json:"pg_settings"`
 }
 
 type Operation struct {
@@ -64,13 +79,16 @@ type ProjectOutput struct {
 }
 
 type ProjectCreateInputProjectBranch struct {
-	Name string `json:"name"`
+	Name         string `json:"name"`
+	RoleName     string `json:"role_name,omitempty"`
+	DatabaseName string `json:"database_name,omitempty"`
 }
 
 type ProjectCreateInputProjectDefaultEndpointSettings struct {
-	AutoscalingLimitMinCu float64 `json:"autoscaling_limit_min_cu"`
-	AutoscalingLimitMaxCu float64 `json:"autoscaling_limit_max_cu"`
-	SuspendTimeoutSeconds int64   `json:"suspend_timeout_seconds"`
+	AutoscalingLimitMinCu float64           `json:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu float64           `json:"autoscaling_limit_max_cu"`
+	SuspendTimeoutSeconds int64             `json:"suspend_timeout_seconds"`
+	PgSettings            map[string]string `json:"pg_settings,omitempty"`
 }
 
 type ProjectCreateInputProject struct {
@@ -83,6 +101,7 @@ type ProjectCreateInputProject struct {
 	Branch                  ProjectCreateInputProjectBranch                  `json:"branch"`
 	DefaultEndpointSettings ProjectCreateInputProjectDefaultEndpointSettings `json:"default_endpoint_settings"`
 	Settings                ProjectSettings                                  `json:"settings"`
+	Provisioner             string                                           `json:"provisioner,omitempty"`
 }
 
 type ProjectCreateInput struct {
@@ -144,6 +163,13 @@ type BranchUpdateInput struct {
 	Branch BranchUpdateInputBranch `json:"branch"`
 }
 
+type BranchRestoreInput struct {
+	SourceBranchId    string  `json:"source_branch_id"`
+	SourceLsn         *string `json:"source_lsn,omitempty"`
+	SourceTimestamp   *string `json:"source_timestamp,omitempty"`
+	PreserveUnderName *string `json:"preserve_under_name,omitempty"`
+}
+
 type BranchEndpointListOutput struct {
 	Endpoints []Endpoint `json:"endpoints"`
 }
@@ -153,11 +179,16 @@ type EndpointOutput struct {
 }
 
 type EndpointCreateInputEndpoint struct {
-	BranchId              string  `json:"branch_id"`
-	Type                  string  `json:"type"`
-	AutoscalingLimitMinCu float64 `json:"autoscaling_limit_min_cu"`
-	AutoscalingLimitMaxCu float64 `json:"autoscaling_limit_max_cu"`
-	SuspendTimeoutSeconds int64   `json:"suspend_timeout_seconds"`
+	BranchId              string            `json:"branch_id"`
+	Type                  string            `json:"type"`
+	AutoscalingLimitMinCu float64           `json:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu float64           `json:"autoscaling_limit_max_cu"`
+	SuspendTimeoutSeconds int64             `json:"suspend_timeout_seconds"`
+	Settings              *EndpointSettings `json:"settings,omitempty"`
+	PoolerEnabled         bool              `json:"pooler_enabled"`
+	PoolerMode            string            `json:"pooler_mode,omitempty"`
+	Disabled              bool              `json:"disabled"`
+	Provisioner           string            `json:"provisioner,omitempty"`
 }
 
 type EndpointCreateInput struct {
@@ -165,9 +196,14 @@ type EndpointCreateInput struct {
 }
 
 type EndpointUpdateInputEndpoint struct {
-	AutoscalingLimitMinCu float64 `json:"autoscaling_limit_min_cu"`
-	AutoscalingLimitMaxCu float64 `json:"autoscaling_limit_max_cu"`
-	SuspendTimeoutSeconds int64   `json:"suspend_timeout_seconds"`
+	AutoscalingLimitMinCu float64           `json:"autoscaling_limit_min_cu"`
+	AutoscalingLimitMaxCu float64           `json:"autoscaling_limit_max_cu"`
+	SuspendTimeoutSeconds int64             `json:"suspend_timeout_seconds"`
+	Settings              *EndpointSettings `json:"settings,omitempty"`
+	PoolerEnabled         bool              `json:"pooler_enabled"`
+	PoolerMode            string            `json:"pooler_mode,omitempty"`
+	Disabled              bool              `json:"disabled"`
+	Provisioner           string            `json:"provisioner,omitempty"`
 }
 
 type EndpointUpdateInput struct {
@@ -178,6 +214,10 @@ type OperationListOutput struct {
 	Operations []Operation `json:"operations"`
 }
 
+type RoleListOutput struct {
+	Roles []Role `json:"roles"`
+}
+
 type RoleOutput struct {
 	Role Role `json:"role"`
 }
@@ -198,6 +238,10 @@ type DatabaseOutput struct {
 	Database Database `json:"database"`
 }
 
+type DatabaseListOutput struct {
+	Databases []Database `json:"databases"`
+}
+
 type DatabaseCreateInputDatabase struct {
 	Name      string `json:"name"`
 	OwnerName string `json:"owner_name"`
@@ -0,0 +1,919 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// sqlConnect connects to a database of a branch as the given role, or as the
+// owner of the database when no role is given.
+func sqlConnect(
+	ctx context.Context,
+	client *http.Client,
+	projectId string,
+	branchId string,
+	databaseName string,
+	roleName string,
+) (*pgx.Conn, error) {
+	if roleName == "" {
+		database, err := databaseGet(client, projectId, branchId, databaseName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		roleName = database.Database.OwnerName
+	}
+
+	uri, err := connectionURI(
+		client,
+		projectId,
+		ConnectionURIInput{
+			BranchId:     &branchId,
+			DatabaseName: databaseName,
+			RoleName:     roleName,
+			Pooled:       false,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.Connect(ctx, uri.URI)
+}
+
+func sqlIdentifier(parts ...string) string {
+	return pgx.Identifier(parts).Sanitize()
+}
+
+func sqlInTx(ctx context.Context, conn *pgx.Conn, statements ...string) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.Exec(ctx, statement); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+type Grant struct {
+	Database        string
+	Role            string
+	ObjectType      string
+	Schema          string
+	Objects         []string
+	Privileges      []string
+	WithGrantOption bool
+}
+
+var grantPrivileges = map[string][]string{
+	"database": {"CREATE", "CONNECT", "TEMPORARY"},
+	"schema":   {"CREATE", "USAGE"},
+	"table":    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"sequence": {"USAGE", "SELECT", "UPDATE"},
+}
+
+var grantRelkinds = map[string][]string{
+	"table":    {"r", "p", "v", "m", "f"},
+	"sequence": {"S"},
+}
+
+func grantTarget(grant Grant) string {
+	switch grant.ObjectType {
+	case "database":
+		return "DATABASE " + sqlIdentifier(grant.Database)
+	case "schema":
+		return "SCHEMA " + sqlIdentifier(grant.Schema)
+	}
+
+	keyword := strings.ToUpper(grant.ObjectType)
+
+	if len(grant.Objects) == 0 {
+		return fmt.Sprintf("ALL %sS IN SCHEMA %s", keyword, sqlIdentifier(grant.Schema))
+	}
+
+	objects := make([]string, len(grant.Objects))
+
+	for i, object := range grant.Objects {
+		objects[i] = sqlIdentifier(grant.Schema, object)
+	}
+
+	return keyword + " " + strings.Join(objects, ", ")
+}
+
+func grantStatement(grant Grant) string {
+	statement := fmt.Sprintf(
+		"GRANT %s ON %s TO %s",
+		strings.Join(grant.Privileges, ", "),
+		grantTarget(grant),
+		sqlIdentifier(grant.Role),
+	)
+
+	if grant.WithGrantOption {
+		statement += " WITH GRANT OPTION"
+	}
+
+	return statement
+}
+
+// revokeStatement revokes only the given privileges, so privileges granted by
+// other grants on the same objects are kept. With grantOption only the grant
+// option for them is revoked.
+func revokeStatement(grant Grant, privileges []string, grantOption bool) string {
+	statement := "REVOKE "
+
+	if grantOption {
+		statement += "GRANT OPTION FOR "
+	}
+
+	return statement + fmt.Sprintf("%s ON %s FROM %s", strings.Join(privileges, ", "), grantTarget(grant), sqlIdentifier(grant.Role))
+}
+
+func grantCreate(ctx context.Context, conn *pgx.Conn, grant Grant) error {
+	return sqlInTx(ctx, conn, grantStatement(grant))
+}
+
+// grantUpdate revokes the privileges of current that are no longer granted,
+// and the grant option when it is dropped, before granting the new ones.
+func grantUpdate(ctx context.Context, conn *pgx.Conn, current Grant, grant Grant) error {
+	var removed, kept []string
+
+	for _, privilege := range current.Privileges {
+		if slices.Contains(grant.Privileges, privilege) {
+			kept = append(kept, privilege)
+		} else {
+			removed = append(removed, privilege)
+		}
+	}
+
+	statements := []string{}
+
+	if len(removed) > 0 {
+		statements = append(statements, revokeStatement(current, removed, false))
+	}
+
+	if current.WithGrantOption && !grant.WithGrantOption && len(kept) > 0 {
+		statements = append(statements, revokeStatement(current, kept, true))
+	}
+
+	statements = append(statements, grantStatement(grant))
+
+	return sqlInTx(ctx, conn, statements...)
+}
+
+func grantDelete(ctx context.Context, conn *pgx.Conn, grant Grant) error {
+	return sqlInTx(ctx, conn, revokeStatement(grant, grant.Privileges, false))
+}
+
+// grantRead returns the privileges the role holds on every object targeted by
+// the grant, and whether all of them are held with grant option. When a grant
+// targets all objects in an empty schema, the privileges of the grant are
+// returned as they are since there is nothing to compare them against.
+func grantRead(ctx context.Context, conn *pgx.Conn, grant Grant) (Grant, error) {
+	var rows pgx.Rows
+	var err error
+
+	switch grant.ObjectType {
+	case "database":
+		rows, err = conn.Query(
+			ctx,
+			`SELECT d.datname, a.privilege_type, a.is_grantable
+			FROM pg_database d
+			CROSS JOIN LATERAL aclexplode(d.datacl) a
+			JOIN pg_roles r ON r.oid = a.grantee
+			WHERE d.datname = $1 AND r.rolname = $2`,
+			grant.Database, grant.Role,
+		)
+	case "schema":
+		rows, err = conn.Query(
+			ctx,
+			`SELECT n.nspname, a.privilege_type, a.is_grantable
+			FROM pg_namespace n
+			CROSS JOIN LATERAL aclexplode(n.nspacl) a
+			JOIN pg_roles r ON r.oid = a.grantee
+			WHERE n.nspname = $1 AND r.rolname = $2`,
+			grant.Schema, grant.Role,
+		)
+	default:
+		var objects []string
+
+		objects, err = grantObjects(ctx, conn, grant)
+
+		if err != nil {
+			return grant, err
+		}
+
+		if len(objects) == 0 {
+			return grant, nil
+		}
+
+		rows, err = conn.Query(
+			ctx,
+			`SELECT c.relname, a.privilege_type, a.is_grantable
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			CROSS JOIN LATERAL aclexplode(c.relacl) a
+			JOIN pg_roles r ON r.oid = a.grantee
+			WHERE n.nspname = $1 AND c.relname = ANY($2) AND c.relkind::text = ANY($3) AND r.rolname = $4`,
+			grant.Schema, objects, grantRelkinds[grant.ObjectType], grant.Role,
+		)
+
+		if err != nil {
+			return grant, err
+		}
+
+		return grantCollect(rows, grant, len(objects))
+	}
+
+	if err != nil {
+		return grant, err
+	}
+
+	return grantCollect(rows, grant, 1)
+}
+
+func grantObjects(ctx context.Context, conn *pgx.Conn, grant Grant) ([]string, error) {
+	rows, err := conn.Query(
+		ctx,
+		`SELECT c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relkind::text = ANY($2) AND (coalesce(cardinality($3::text[]), 0) = 0 OR c.relname = ANY($3))`,
+		grant.Schema, grantRelkinds[grant.ObjectType], grant.Objects,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// grantCollect only looks at the privileges of the grant, so privileges held
+// through other grants on the same objects are not reported as drift.
+func grantCollect(rows pgx.Rows, grant Grant, objects int) (Grant, error) {
+	managed := []string{}
+
+	for _, privilege := range grantPrivileges[grant.ObjectType] {
+		if slices.Contains(grant.Privileges, privilege) {
+			managed = append(managed, privilege)
+		}
+	}
+
+	privileges, grantable, err := aclCollect(rows, managed, objects)
+
+	if err != nil {
+		return grant, err
+	}
+
+	grant.Privileges = privileges
+	grant.WithGrantOption = grantable
+
+	return grant, nil
+}
+
+// aclCollect reads rows of object, privilege and grantable columns, and returns
+// the privileges held on the given number of objects in the order they are
+// allowed, and whether all of them are held with grant option. A privilege can
+// be granted by several grantors, so objects are counted once per privilege.
+func aclCollect(rows pgx.Rows, allowed []string, objects int) ([]string, bool, error) {
+	held := map[string]map[string]bool{}
+
+	var object, privilege string
+	var isGrantable bool
+
+	_, err := pgx.ForEachRow(rows, []any{&object, &privilege, &isGrantable}, func() error {
+		if held[privilege] == nil {
+			held[privilege] = map[string]bool{}
+		}
+
+		held[privilege][object] = held[privilege][object] || isGrantable
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	privileges := []string{}
+	grantable := true
+
+	for _, privilege := range allowed {
+		if len(held[privilege]) != objects {
+			continue
+		}
+
+		privileges = append(privileges, privilege)
+
+		for _, isGrantable := range held[privilege] {
+			grantable = grantable && isGrantable
+		}
+	}
+
+	return privileges, len(privileges) > 0 && grantable, nil
+}
+
+type Extension struct {
+	Name    string
+	Version string
+	Schema  string
+}
+
+func extensionCreate(ctx context.Context, conn *pgx.Conn, extension Extension) error {
+	statement := "CREATE EXTENSION " + sqlIdentifier(extension.Name)
+
+	if extension.Schema != "" {
+		statement += " SCHEMA " + sqlIdentifier(extension.Schema)
+	}
+
+	if extension.Version != "" {
+		statement += " VERSION " + sqlIdentifier(extension.Version)
+	}
+
+	return sqlInTx(ctx, conn, statement)
+}
+
+// extensionRead returns the installed extension, or nil when it is not installed.
+func extensionRead(ctx context.Context, conn *pgx.Conn, name string) (*Extension, error) {
+	extension := Extension{Name: name}
+
+	err := conn.QueryRow(
+		ctx,
+		`SELECT e.extversion, n.nspname
+		FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		WHERE e.extname = $1`,
+		name,
+	).Scan(&extension.Version, &extension.Schema)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &extension, nil
+}
+
+func extensionUpdate(ctx context.Context, conn *pgx.Conn, current Extension, extension Extension) error {
+	var statements []string
+
+	if extension.Version != "" && extension.Version != current.Version {
+		statements = append(statements, fmt.Sprintf("ALTER EXTENSION %s UPDATE TO %s", sqlIdentifier(extension.Name), sqlIdentifier(extension.Version)))
+	}
+
+	if extension.Schema != "" && extension.Schema != current.Schema {
+		statements = append(statements, fmt.Sprintf("ALTER EXTENSION %s SET SCHEMA %s", sqlIdentifier(extension.Name), sqlIdentifier(extension.Schema)))
+	}
+
+	return sqlInTx(ctx, conn, statements...)
+}
+
+func extensionDelete(ctx context.Context, conn *pgx.Conn, name string) error {
+	return sqlInTx(ctx, conn, "DROP EXTENSION "+sqlIdentifier(name))
+}
+
+type Schema struct {
+	Name  string
+	Owner string
+}
+
+func schemaCreate(ctx context.Context, conn *pgx.Conn, schema Schema, ifNotExists bool) error {
+	statement := "CREATE SCHEMA "
+
+	if ifNotExists {
+		statement += "IF NOT EXISTS "
+	}
+
+	statements := []string{statement + sqlIdentifier(schema.Name)}
+
+	if schema.Owner != "" {
+		statements = append(statements, fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", sqlIdentifier(schema.Name), sqlIdentifier(schema.Owner)))
+	}
+
+	return sqlInTx(ctx, conn, statements...)
+}
+
+// schemaRead returns the schema, or nil when it does not exist.
+func schemaRead(ctx context.Context, conn *pgx.Conn, name string) (*Schema, error) {
+	schema := Schema{Name: name}
+
+	err := conn.QueryRow(
+		ctx,
+		"SELECT pg_get_userbyid(nspowner) FROM pg_namespace WHERE nspname = $1",
+		name,
+	).Scan(&schema.Owner)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+func schemaUpdate(ctx context.Context, conn *pgx.Conn, current Schema, schema Schema) error {
+	var statements []string
+
+	if schema.Name != current.Name {
+		statements = append(statements, fmt.Sprintf("ALTER SCHEMA %s RENAME TO %s", sqlIdentifier(current.Name), sqlIdentifier(schema.Name)))
+	}
+
+	if schema.Owner != "" && schema.Owner != current.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", sqlIdentifier(schema.Name), sqlIdentifier(schema.Owner)))
+	}
+
+	return sqlInTx(ctx, conn, statements...)
+}
+
+func schemaDelete(ctx context.Context, conn *pgx.Conn, name string, cascade bool) error {
+	statement := "DROP SCHEMA " + sqlIdentifier(name)
+
+	if cascade {
+		statement += " CASCADE"
+	}
+
+	return sqlInTx(ctx, conn, statement)
+}
+
+type DefaultPrivileges struct {
+	Owner           string
+	Role            string
+	ObjectType      string
+	Schema          string
+	Privileges      []string
+	WithGrantOption bool
+}
+
+var defaultPrivilegesPrivileges = map[string][]string{
+	"table":    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"sequence": {"USAGE", "SELECT", "UPDATE"},
+	"function": {"EXECUTE"},
+	"type":     {"USAGE"},
+	"schema":   {"CREATE", "USAGE"},
+}
+
+var defaultPrivilegesObjtypes = map[string]string{
+	"table":    "r",
+	"sequence": "S",
+	"function": "f",
+	"type":     "T",
+	"schema":   "n",
+}
+
+func defaultPrivilegesPrefix(privileges DefaultPrivileges) string {
+	prefix := "ALTER DEFAULT PRIVILEGES FOR ROLE " + sqlIdentifier(privileges.Owner)
+
+	if privileges.Schema != "" {
+		prefix += " IN SCHEMA " + sqlIdentifier(privileges.Schema)
+	}
+
+	return prefix
+}
+
+func defaultPrivilegesGrantStatement(privileges DefaultPrivileges) string {
+	statement := fmt.Sprintf(
+		"%s GRANT %s ON %sS TO %s",
+		defaultPrivilegesPrefix(privileges),
+		strings.Join(privileges.Privileges, ", "),
+		strings.ToUpper(privileges.ObjectType),
+		sqlIdentifier(privileges.Role),
+	)
+
+	if privileges.WithGrantOption {
+		statement += " WITH GRANT OPTION"
+	}
+
+	return statement
+}
+
+func defaultPrivilegesRevokeStatement(privileges DefaultPrivileges) string {
+	return fmt.Sprintf(
+		"%s REVOKE ALL ON %sS FROM %s",
+		defaultPrivilegesPrefix(privileges),
+		strings.ToUpper(privileges.ObjectType),
+		sqlIdentifier(privileges.Role),
+	)
+}
+
+func defaultPrivilegesCreate(ctx context.Context, conn *pgx.Conn, privileges DefaultPrivileges) error {
+	return sqlInTx(ctx, conn, defaultPrivilegesGrantStatement(privileges))
+}
+
+func defaultPrivilegesUpdate(ctx context.Context, conn *pgx.Conn, privileges DefaultPrivileges) error {
+	return sqlInTx(ctx, conn, defaultPrivilegesRevokeStatement(privileges), defaultPrivilegesGrantStatement(privileges))
+}
+
+func defaultPrivilegesDelete(ctx context.Context, conn *pgx.Conn, privileges DefaultPrivileges) error {
+	return sqlInTx(ctx, conn, defaultPrivilegesRevokeStatement(privileges))
+}
+
+func defaultPrivilegesRead(ctx context.Context, conn *pgx.Conn, privileges DefaultPrivileges) (DefaultPrivileges, error) {
+	rows, err := conn.Query(
+		ctx,
+		`SELECT coalesce(n.nspname, ''), a.privilege_type, a.is_grantable
+		FROM pg_default_acl d
+		LEFT JOIN pg_namespace n ON n.oid = d.defaclnamespace
+		CROSS JOIN LATERAL aclexplode(d.defaclacl) a
+		JOIN pg_roles r ON r.oid = a.grantee
+		WHERE pg_get_userbyid(d.defaclrole) = $1 AND d.defaclobjtype::text = $2 AND coalesce(n.nspname, '') = $3 AND r.rolname = $4`,
+		privileges.Owner, defaultPrivilegesObjtypes[privileges.ObjectType], privileges.Schema, privileges.Role,
+	)
+
+	if err != nil {
+		return privileges, err
+	}
+
+	held, grantable, err := aclCollect(rows, defaultPrivilegesPrivileges[privileges.ObjectType], 1)
+
+	if err != nil {
+		return privileges, err
+	}
+
+	privileges.Privileges = held
+	privileges.WithGrantOption = grantable
+
+	return privileges, nil
+}
+
+type RoleMembership struct {
+	Group       string
+	Member      string
+	Inherit     bool
+	AdminOption bool
+}
+
+// sqlServerVersion returns the version of the server as a number, for example
+// 160002 for 16.2.
+func sqlServerVersion(ctx context.Context, conn *pgx.Conn) (int, error) {
+	var version int
+
+	err := conn.QueryRow(ctx, "SELECT current_setting('server_version_num')::int").Scan(&version)
+
+	return version, err
+}
+
+// roleRename renames a role, which the Neon API cannot do. A role cannot rename itself, so conn
+// must belong to another role.
+func roleRename(ctx context.Context, conn *pgx.Conn, name string, newName string) error {
+	return sqlInTx(ctx, conn, fmt.Sprintf("ALTER ROLE %s RENAME TO %s", sqlIdentifier(name), sqlIdentifier(newName)))
+}
+
+func roleMembershipGrantStatement(ctx context.Context, conn *pgx.Conn, membership RoleMembership) (string, error) {
+	version, err := sqlServerVersion(ctx, conn)
+
+	if err != nil {
+		return "", err
+	}
+
+	statement := fmt.Sprintf("GRANT %s TO %s", sqlIdentifier(membership.Group), sqlIdentifier(membership.Member))
+
+	// Inheritance can only be set for each membership since Postgres 16.
+	if version >= 160000 {
+		return statement + fmt.Sprintf(" WITH ADMIN %t, INHERIT %t", membership.AdminOption, membership.Inherit), nil
+	}
+
+	if !membership.Inherit {
+		return "", fmt.Errorf("memberships without inherit need Postgres 16 or later")
+	}
+
+	if membership.AdminOption {
+		statement += " WITH ADMIN OPTION"
+	}
+
+	return statement, nil
+}
+
+func roleMembershipRevokeStatement(membership RoleMembership) string {
+	return fmt.Sprintf("REVOKE %s FROM %s", sqlIdentifier(membership.Group), sqlIdentifier(membership.Member))
+}
+
+func roleMembershipCreate(ctx context.Context, conn *pgx.Conn, membership RoleMembership) error {
+	statement, err := roleMembershipGrantStatement(ctx, conn, membership)
+
+	if err != nil {
+		return err
+	}
+
+	return sqlInTx(ctx, conn, statement)
+}
+
+func roleMembershipUpdate(ctx context.Context, conn *pgx.Conn, membership RoleMembership) error {
+	statement, err := roleMembershipGrantStatement(ctx, conn, membership)
+
+	if err != nil {
+		return err
+	}
+
+	return sqlInTx(ctx, conn, roleMembershipRevokeStatement(membership), statement)
+}
+
+func roleMembershipDelete(ctx context.Context, conn *pgx.Conn, membership RoleMembership) error {
+	return sqlInTx(ctx, conn, roleMembershipRevokeStatement(membership))
+}
+
+// roleMembershipRead returns the membership, or nil when the member is not in
+// the group. Before Postgres 16 inheritance is a property of the member.
+func roleMembershipRead(ctx context.Context, conn *pgx.Conn, group string, member string) (*RoleMembership, error) {
+	version, err := sqlServerVersion(ctx, conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	inherit := "r.rolinherit"
+
+	if version >= 160000 {
+		inherit = "m.inherit_option"
+	}
+
+	membership := RoleMembership{Group: group, Member: member}
+
+	err = conn.QueryRow(
+		ctx,
+		fmt.Sprintf(
+			`SELECT bool_or(%s), bool_or(m.admin_option)
+			FROM pg_auth_members m
+			JOIN pg_roles g ON g.oid = m.roleid
+			JOIN pg_roles r ON r.oid = m.member
+			WHERE g.rolname = $1 AND r.rolname = $2
+			HAVING count(*) > 0`,
+			inherit,
+		),
+		group, member,
+	).Scan(&membership.Inherit, &membership.AdminOption)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &membership, nil
+}
+
+type Publication struct {
+	Name      string
+	AllTables bool
+	Tables    []string
+	Publish   []string
+}
+
+var publicationOperations = []string{"insert", "update", "delete", "truncate"}
+
+func publicationTables(tables []string) string {
+	identifiers := make([]string, len(tables))
+
+	for i, table := range tables {
+		schema, name, _ := strings.Cut(table, ".")
+		identifiers[i] = sqlIdentifier(schema, name)
+	}
+
+	return strings.Join(identifiers, ", ")
+}
+
+func publicationPublish(publication Publication) string {
+	return fmt.Sprintf("ALTER PUBLICATION %s SET (publish = '%s')", sqlIdentifier(publication.Name), strings.Join(publication.Publish, ", "))
+}
+
+func publicationCreate(ctx context.Context, conn *pgx.Conn, publication Publication) error {
+	statement := "CREATE PUBLICATION " + sqlIdentifier(publication.Name)
+
+	if publication.AllTables {
+		statement += " FOR ALL TABLES"
+	} else if len(publication.Tables) > 0 {
+		statement += " FOR TABLE " + publicationTables(publication.Tables)
+	}
+
+	statements := []string{statement}
+
+	if publication.Publish != nil {
+		statements = append(statements, publicationPublish(publication))
+	}
+
+	return sqlInTx(ctx, conn, statements...)
+}
+
+// publicationRead returns the publication with its tables as schema.table, or
+// nil when it does not exist.
+func publicationRead(ctx context.Context, conn *pgx.Conn, name string) (*Publication, error) {
+	publication := Publication{Name: name, Publish: []string{}}
+
+	var insert, update, delete, truncate bool
+
+	err := conn.QueryRow(
+		ctx,
+		"SELECT puballtables, pubinsert, pubupdate, pubdelete, pubtruncate FROM pg_publication WHERE pubname = $1",
+		name,
+	).Scan(&publication.AllTables, &insert, &update, &delete, &truncate)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, enabled := range []bool{insert, update, delete, truncate} {
+		if enabled {
+			publication.Publish = append(publication.Publish, publicationOperations[i])
+		}
+	}
+
+	if publication.AllTables {
+		return &publication, nil
+	}
+
+	rows, err := conn.Query(
+		ctx,
+		"SELECT schemaname || '.' || tablename FROM pg_publication_tables WHERE pubname = $1 ORDER BY 1",
+		name,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	publication.Tables, err = pgx.CollectRows(rows, pgx.RowTo[string])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &publication, nil
+}
+
+func publicationUpdate(ctx context.Context, conn *pgx.Conn, current Publication, publication Publication) error {
+	var statements []string
+
+	if !publication.AllTables {
+		if len(publication.Tables) > 0 {
+			statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s SET TABLE %s", sqlIdentifier(publication.Name), publicationTables(publication.Tables)))
+		} else if len(current.Tables) > 0 {
+			statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s DROP TABLE %s", sqlIdentifier(publication.Name), publicationTables(current.Tables)))
+		}
+	}
+
+	if publication.Publish != nil {
+		statements = append(statements, publicationPublish(publication))
+	}
+
+	return sqlInTx(ctx, conn, statements...)
+}
+
+func publicationDelete(ctx context.Context, conn *pgx.Conn, name string) error {
+	return sqlInTx(ctx, conn, "DROP PUBLICATION "+sqlIdentifier(name))
+}
+
+type ReplicationSlot struct {
+	Name     string
+	Plugin   string
+	Database string
+}
+
+// replicationSlotCreate runs outside of a transaction, since logical slots can
+// not be created in a transaction which performed writes.
+func replicationSlotCreate(ctx context.Context, conn *pgx.Conn, slot ReplicationSlot) error {
+	_, err := conn.Exec(ctx, "SELECT pg_create_logical_replication_slot($1, $2)", slot.Name, slot.Plugin)
+
+	return err
+}
+
+// replicationSlotRead returns the logical replication slot, or nil when it does
+// not exist.
+func replicationSlotRead(ctx context.Context, conn *pgx.Conn, name string) (*ReplicationSlot, error) {
+	slot := ReplicationSlot{Name: name}
+
+	err := conn.QueryRow(
+		ctx,
+		"SELECT plugin, database FROM pg_replication_slots WHERE slot_name = $1 AND slot_type = 'logical'",
+		name,
+	).Scan(&slot.Plugin, &slot.Database)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &slot, nil
+}
+
+func replicationSlotDelete(ctx context.Context, conn *pgx.Conn, name string) error {
+	_, err := conn.Exec(ctx, "SELECT pg_drop_replication_slot($1)", name)
+
+	return err
+}
+
+type DatabaseLocale struct {
+	Encoding       string
+	LcCollate      string
+	LcCtype        string
+	LocaleProvider string
+	IcuLocale      string
+	Template       string
+}
+
+func sqlLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// databaseCreateWithLocale creates a database with the locale options the API
+// does not support. It runs outside of a transaction, as CREATE DATABASE can
+// not run in one.
+func databaseCreateWithLocale(ctx context.Context, conn *pgx.Conn, name string, owner string, locale DatabaseLocale) error {
+	statement := fmt.Sprintf("CREATE DATABASE %s OWNER %s", sqlIdentifier(name), sqlIdentifier(owner))
+
+	// Other locales than the one of template1 need a template without data.
+	template := locale.Template
+
+	if template == "" {
+		template = "template0"
+	}
+
+	statement += " TEMPLATE " + sqlIdentifier(template)
+
+	if locale.Encoding != "" {
+		statement += " ENCODING " + sqlLiteral(locale.Encoding)
+	}
+
+	if locale.LcCollate != "" {
+		statement += " LC_COLLATE " + sqlLiteral(locale.LcCollate)
+	}
+
+	if locale.LcCtype != "" {
+		statement += " LC_CTYPE " + sqlLiteral(locale.LcCtype)
+	}
+
+	if locale.LocaleProvider != "" {
+		statement += " LOCALE_PROVIDER " + locale.LocaleProvider
+	}
+
+	if locale.IcuLocale != "" {
+		statement += " ICU_LOCALE " + sqlLiteral(locale.IcuLocale)
+	}
+
+	_, err := conn.Exec(ctx, statement)
+
+	return err
+}
+
+// databaseLocaleRead returns the locale options of a database, or nil when it
+// does not exist. The template is not kept by Postgres, so it is left empty.
+// The locale columns are read through to_jsonb, since they differ between
+// Postgres versions.
+func databaseLocaleRead(ctx context.Context, conn *pgx.Conn, name string) (*DatabaseLocale, error) {
+	locale := DatabaseLocale{}
+
+	var provider string
+
+	err := conn.QueryRow(
+		ctx,
+		`SELECT pg_encoding_to_char(d.encoding),
+			COALESCE(d.datcollate::text, ''),
+			COALESCE(d.datctype::text, ''),
+			COALESCE(to_jsonb(d) ->> 'datlocprovider', 'c'),
+			COALESCE(to_jsonb(d) ->> 'datlocale', to_jsonb(d) ->> 'daticulocale', '')
+		FROM pg_database d
+		WHERE d.datname = $1`,
+		name,
+	).Scan(&locale.Encoding, &locale.LcCollate, &locale.LcCtype, &provider, &locale.IcuLocale)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch provider {
+	case "i":
+		locale.LocaleProvider = "icu"
+	case "b":
+		locale.LocaleProvider = "builtin"
+	default:
+		locale.LocaleProvider = "libc"
+	}
+
+	return &locale, nil
+}
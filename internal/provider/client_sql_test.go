@@ -0,0 +1,695 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestSQLGrant(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	err := sqlInTx(
+		ctx, conn,
+		"DROP SCHEMA IF EXISTS grant_test CASCADE",
+		"DROP ROLE IF EXISTS grant_test_reporting",
+		"DROP ROLE IF EXISTS grant_test_admin",
+		"CREATE ROLE grant_test_reporting",
+		"CREATE ROLE grant_test_admin",
+		"CREATE SCHEMA grant_test",
+		"CREATE TABLE grant_test.orders (id int)",
+		"CREATE TABLE grant_test.users (id int)",
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		sqlInTx(ctx, conn, "DROP SCHEMA grant_test CASCADE", "DROP ROLE grant_test_reporting", "DROP ROLE grant_test_admin")
+	})
+
+	grant := Grant{
+		Role:       "grant_test_reporting",
+		ObjectType: "table",
+		Schema:     "grant_test",
+		Privileges: []string{"SELECT"},
+	}
+
+	if err := grantCreate(ctx, conn, grant); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := grantRead(ctx, conn, grant)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(read.Privileges, []string{"SELECT"}) || read.WithGrantOption {
+		t.Fatalf("unexpected grant after create: %+v", read)
+	}
+
+	// A table created after the grant does not have the privileges, which is drift.
+	if err := sqlInTx(ctx, conn, "CREATE TABLE grant_test.invoices (id int)"); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = grantRead(ctx, conn, grant)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(read.Privileges) != 0 {
+		t.Fatalf("unexpected grant after new table: %+v", read)
+	}
+
+	current := grant
+
+	grant.Privileges = []string{"INSERT", "SELECT"}
+	grant.WithGrantOption = true
+
+	if err := grantUpdate(ctx, conn, current, grant); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = grantRead(ctx, conn, grant)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(read.Privileges, []string{"SELECT", "INSERT"}) || !read.WithGrantOption {
+		t.Fatalf("unexpected grant after update: %+v", read)
+	}
+
+	// Privileges granted elsewhere on the same objects survive updates and delete.
+	if err := sqlInTx(ctx, conn, "GRANT UPDATE ON ALL TABLES IN SCHEMA grant_test TO grant_test_reporting"); err != nil {
+		t.Fatal(err)
+	}
+
+	current = grant
+
+	grant.Privileges = []string{"SELECT"}
+	grant.WithGrantOption = false
+
+	if err := grantUpdate(ctx, conn, current, grant); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = grantRead(ctx, conn, Grant{Role: grant.Role, ObjectType: "table", Schema: "grant_test", Privileges: []string{"SELECT", "INSERT", "UPDATE"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(read.Privileges, []string{"SELECT", "UPDATE"}) || read.WithGrantOption {
+		t.Fatalf("unexpected grant after narrowing update: %+v", read)
+	}
+
+	// The privilege granted elsewhere is not part of this grant.
+	read, err = grantRead(ctx, conn, grant)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(read.Privileges, []string{"SELECT"}) {
+		t.Fatalf("unexpected grant with privileges granted elsewhere: %+v", read)
+	}
+
+	if err := grantDelete(ctx, conn, grant); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = grantRead(ctx, conn, grant)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(read.Privileges) != 0 {
+		t.Fatalf("unexpected grant after delete: %+v", read)
+	}
+
+	// A privilege granted by two grantors is still held once on every object.
+	if err := grantCreate(ctx, conn, grant); err != nil {
+		t.Fatal(err)
+	}
+
+	err = sqlInTx(
+		ctx, conn,
+		"GRANT USAGE ON SCHEMA grant_test TO grant_test_admin",
+		"GRANT SELECT ON ALL TABLES IN SCHEMA grant_test TO grant_test_admin WITH GRANT OPTION",
+		"SET LOCAL ROLE grant_test_admin",
+		"GRANT SELECT ON ALL TABLES IN SCHEMA grant_test TO grant_test_reporting",
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = grantRead(ctx, conn, grant)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(read.Privileges, []string{"SELECT"}) {
+		t.Fatalf("unexpected grant with two grantors: %+v", read)
+	}
+}
+
+func TestSQLExtension(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	err := sqlInTx(
+		ctx, conn,
+		"DROP EXTENSION IF EXISTS hstore",
+		"DROP SCHEMA IF EXISTS extension_test CASCADE",
+		"CREATE SCHEMA extension_test",
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		sqlInTx(ctx, conn, "DROP EXTENSION IF EXISTS hstore", "DROP SCHEMA extension_test CASCADE")
+	})
+
+	if err := extensionCreate(ctx, conn, Extension{Name: "hstore", Version: "1.7"}); err != nil {
+		t.Fatal(err)
+	}
+
+	extension, err := extensionRead(ctx, conn, "hstore")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if extension == nil || extension.Version != "1.7" || extension.Schema != "public" {
+		t.Fatalf("unexpected extension after create: %+v", extension)
+	}
+
+	err = extensionUpdate(ctx, conn, *extension, Extension{Name: "hstore", Version: "1.8", Schema: "extension_test"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extension, err = extensionRead(ctx, conn, "hstore")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if extension == nil || extension.Version != "1.8" || extension.Schema != "extension_test" {
+		t.Fatalf("unexpected extension after update: %+v", extension)
+	}
+
+	if err := extensionDelete(ctx, conn, "hstore"); err != nil {
+		t.Fatal(err)
+	}
+
+	extension, err = extensionRead(ctx, conn, "hstore")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if extension != nil {
+		t.Fatalf("unexpected extension after delete: %+v", extension)
+	}
+}
+
+func TestSQLSchema(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	err := sqlInTx(
+		ctx, conn,
+		"DROP SCHEMA IF EXISTS schema_test CASCADE",
+		"DROP SCHEMA IF EXISTS schema_test_renamed CASCADE",
+		"DROP ROLE IF EXISTS schema_test_tenant",
+		"CREATE ROLE schema_test_tenant",
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		sqlInTx(ctx, conn, "DROP SCHEMA IF EXISTS schema_test_renamed CASCADE", "DROP ROLE schema_test_tenant")
+	})
+
+	if err := schemaCreate(ctx, conn, Schema{Name: "schema_test"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := schemaCreate(ctx, conn, Schema{Name: "schema_test"}, false); err == nil {
+		t.Fatal("expected creating an existing schema to fail")
+	}
+
+	if err := schemaCreate(ctx, conn, Schema{Name: "schema_test", Owner: "schema_test_tenant"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := schemaRead(ctx, conn, "schema_test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema == nil || schema.Owner != "schema_test_tenant" {
+		t.Fatalf("unexpected schema after create: %+v", schema)
+	}
+
+	err = schemaUpdate(ctx, conn, *schema, Schema{Name: "schema_test_renamed", Owner: conn.Config().User})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err = schemaRead(ctx, conn, "schema_test_renamed")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema == nil || schema.Owner != conn.Config().User {
+		t.Fatalf("unexpected schema after update: %+v", schema)
+	}
+
+	if err := sqlInTx(ctx, conn, "CREATE TABLE schema_test_renamed.orders (id int)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := schemaDelete(ctx, conn, "schema_test_renamed", false); err == nil {
+		t.Fatal("expected dropping a schema which is not empty to fail")
+	}
+
+	if err := schemaDelete(ctx, conn, "schema_test_renamed", true); err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err = schemaRead(ctx, conn, "schema_test_renamed")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema != nil {
+		t.Fatalf("unexpected schema after delete: %+v", schema)
+	}
+}
+
+func TestSQLDefaultPrivileges(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	err := sqlInTx(
+		ctx, conn,
+		"DROP SCHEMA IF EXISTS default_privileges_test CASCADE",
+		"DROP ROLE IF EXISTS default_privileges_test_reporting",
+		"CREATE ROLE default_privileges_test_reporting",
+		"CREATE SCHEMA default_privileges_test",
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		sqlInTx(
+			ctx, conn,
+			"DROP SCHEMA default_privileges_test CASCADE",
+			"DROP OWNED BY default_privileges_test_reporting",
+			"DROP ROLE default_privileges_test_reporting",
+		)
+	})
+
+	privileges := DefaultPrivileges{
+		Owner:      conn.Config().User,
+		Role:       "default_privileges_test_reporting",
+		ObjectType: "table",
+		Schema:     "default_privileges_test",
+		Privileges: []string{"SELECT"},
+	}
+
+	if err := defaultPrivilegesCreate(ctx, conn, privileges); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := defaultPrivilegesRead(ctx, conn, privileges)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(read.Privileges, []string{"SELECT"}) || read.WithGrantOption {
+		t.Fatalf("unexpected default privileges after create: %+v", read)
+	}
+
+	// Tables created later receive the privileges.
+	if err := sqlInTx(ctx, conn, "CREATE TABLE default_privileges_test.orders (id int)"); err != nil {
+		t.Fatal(err)
+	}
+
+	grant, err := grantRead(ctx, conn, Grant{Role: privileges.Role, ObjectType: "table", Schema: privileges.Schema, Privileges: []string{"SELECT"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(grant.Privileges, []string{"SELECT"}) {
+		t.Fatalf("unexpected grant on new table: %+v", grant)
+	}
+
+	privileges.Privileges = []string{"SELECT", "UPDATE"}
+	privileges.WithGrantOption = true
+
+	if err := defaultPrivilegesUpdate(ctx, conn, privileges); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = defaultPrivilegesRead(ctx, conn, privileges)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(read.Privileges, []string{"SELECT", "UPDATE"}) || !read.WithGrantOption {
+		t.Fatalf("unexpected default privileges after update: %+v", read)
+	}
+
+	if err := defaultPrivilegesDelete(ctx, conn, privileges); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = defaultPrivilegesRead(ctx, conn, privileges)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(read.Privileges) != 0 {
+		t.Fatalf("unexpected default privileges after delete: %+v", read)
+	}
+}
+
+func TestSQLRoleMembership(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	err := sqlInTx(
+		ctx, conn,
+		"DROP ROLE IF EXISTS role_membership_test_rw",
+		"DROP ROLE IF EXISTS role_membership_test_ro",
+		"CREATE ROLE role_membership_test_ro",
+		"CREATE ROLE role_membership_test_rw",
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		sqlInTx(ctx, conn, "DROP ROLE role_membership_test_rw", "DROP ROLE role_membership_test_ro")
+	})
+
+	membership := RoleMembership{
+		Group:   "role_membership_test_ro",
+		Member:  "role_membership_test_rw",
+		Inherit: true,
+	}
+
+	if err := roleMembershipCreate(ctx, conn, membership); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := roleMembershipRead(ctx, conn, membership.Group, membership.Member)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read == nil || *read != membership {
+		t.Fatalf("unexpected role membership after create: %+v", read)
+	}
+
+	membership.AdminOption = true
+
+	if err := roleMembershipUpdate(ctx, conn, membership); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = roleMembershipRead(ctx, conn, membership.Group, membership.Member)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read == nil || *read != membership {
+		t.Fatalf("unexpected role membership after update: %+v", read)
+	}
+
+	if err := roleMembershipDelete(ctx, conn, membership); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = roleMembershipRead(ctx, conn, membership.Group, membership.Member)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read != nil {
+		t.Fatalf("unexpected role membership after delete: %+v", read)
+	}
+}
+
+func TestSQLRoleRename(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	err := sqlInTx(
+		ctx, conn,
+		"DROP SCHEMA IF EXISTS role_rename_test",
+		"DROP ROLE IF EXISTS role_rename_test",
+		"DROP ROLE IF EXISTS role_rename_test_renamed",
+		"CREATE ROLE role_rename_test",
+		"CREATE SCHEMA role_rename_test AUTHORIZATION role_rename_test",
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		sqlInTx(ctx, conn, "DROP SCHEMA role_rename_test", "DROP ROLE role_rename_test_renamed")
+	})
+
+	if err := roleRename(ctx, conn, "role_rename_test", "role_rename_test_renamed"); err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := schemaRead(ctx, conn, "role_rename_test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema == nil || schema.Owner != "role_rename_test_renamed" {
+		t.Fatalf("unexpected schema owner after rename: %+v", schema)
+	}
+}
+
+func TestSQLPublication(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	err := sqlInTx(
+		ctx, conn,
+		"DROP PUBLICATION IF EXISTS publication_test",
+		"DROP SCHEMA IF EXISTS publication_test CASCADE",
+		"CREATE SCHEMA publication_test",
+		"CREATE TABLE publication_test.orders (id int PRIMARY KEY)",
+		"CREATE TABLE publication_test.users (id int PRIMARY KEY)",
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		sqlInTx(ctx, conn, "DROP PUBLICATION IF EXISTS publication_test", "DROP SCHEMA publication_test CASCADE")
+	})
+
+	publication := Publication{
+		Name:   "publication_test",
+		Tables: []string{"publication_test.orders"},
+	}
+
+	if err := publicationCreate(ctx, conn, publication); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := publicationRead(ctx, conn, publication.Name)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read == nil || !slices.Equal(read.Tables, []string{"publication_test.orders"}) || !slices.Equal(read.Publish, publicationOperations) {
+		t.Fatalf("unexpected publication after create: %+v", read)
+	}
+
+	current := *read
+
+	publication.Tables = []string{"publication_test.orders", "publication_test.users"}
+	publication.Publish = []string{"insert"}
+
+	if err := publicationUpdate(ctx, conn, current, publication); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = publicationRead(ctx, conn, publication.Name)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read == nil || !slices.Equal(read.Tables, publication.Tables) || !slices.Equal(read.Publish, []string{"insert"}) {
+		t.Fatalf("unexpected publication after update: %+v", read)
+	}
+
+	current = *read
+
+	publication.Tables = nil
+
+	if err := publicationUpdate(ctx, conn, current, publication); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = publicationRead(ctx, conn, publication.Name)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read == nil || len(read.Tables) != 0 {
+		t.Fatalf("unexpected publication after dropping tables: %+v", read)
+	}
+
+	if err := publicationDelete(ctx, conn, publication.Name); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err = publicationRead(ctx, conn, publication.Name)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read != nil {
+		t.Fatalf("unexpected publication after delete: %+v", read)
+	}
+}
+
+func TestSQLReplicationSlot(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	var walLevel string
+
+	if err := conn.QueryRow(ctx, "SHOW wal_level").Scan(&walLevel); err != nil {
+		t.Fatal(err)
+	}
+
+	if walLevel != "logical" {
+		t.Skip("wal_level must be logical for replication slot tests")
+	}
+
+	if err := replicationSlotCreate(ctx, conn, ReplicationSlot{Name: "replication_slot_test", Plugin: "pgoutput"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		replicationSlotDelete(ctx, conn, "replication_slot_test")
+	})
+
+	slot, err := replicationSlotRead(ctx, conn, "replication_slot_test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if slot == nil || slot.Plugin != "pgoutput" || slot.Database != conn.Config().Database {
+		t.Fatalf("unexpected replication slot after create: %+v", slot)
+	}
+
+	if err := replicationSlotDelete(ctx, conn, "replication_slot_test"); err != nil {
+		t.Fatal(err)
+	}
+
+	slot, err = replicationSlotRead(ctx, conn, "replication_slot_test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if slot != nil {
+		t.Fatalf("unexpected replication slot after delete: %+v", slot)
+	}
+}
+
+func TestSQLDatabaseCreateWithLocale(t *testing.T) {
+	ctx := context.Background()
+	conn := testSQLConnect(t)
+
+	if _, err := conn.Exec(ctx, "DROP DATABASE IF EXISTS locale_test"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Exec(ctx, "DROP DATABASE IF EXISTS locale_test")
+	})
+
+	err := databaseCreateWithLocale(ctx, conn, "locale_test", conn.Config().User, DatabaseLocale{
+		Encoding:  "UTF8",
+		LcCollate: "C",
+		LcCtype:   "C",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	locale, err := databaseLocaleRead(ctx, conn, "locale_test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if locale == nil {
+		t.Fatal("expected database to exist")
+	}
+
+	if locale.Encoding != "UTF8" || locale.LcCollate != "C" || locale.LcCtype != "C" || locale.LocaleProvider != "libc" {
+		t.Fatalf("unexpected database locale: %+v", *locale)
+	}
+
+	locale, err = databaseLocaleRead(ctx, conn, "locale_test_missing")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if locale != nil {
+		t.Fatalf("expected no locale for missing database, got %+v", *locale)
+	}
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ ephemeral.EphemeralResource = &ConnectionURIEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ConnectionURIEphemeralResource{}
+
+func NewConnectionURIEphemeralResource() ephemeral.EphemeralResource {
+	return &ConnectionURIEphemeralResource{}
+}
+
+type ConnectionURIEphemeralResource struct {
+	client *http.Client
+}
+
+type ConnectionURIEphemeralResourceModel struct {
+	ProjectId    types.String `tfsdk:"project_id"`
+	BranchId     types.String `tfsdk:"branch_id"`
+	EndpointId   types.String `tfsdk:"endpoint_id"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	RoleName     types.String `tfsdk:"role_name"`
+	URI          types.String `tfsdk:"uri"`
+	PooledURI    types.String `tfsdk:"pooled_uri"`
+	Password     types.String `tfsdk:"password"`
+}
+
+func (e *ConnectionURIEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_uri"
+}
+
+func (e *ConnectionURIEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves normal and pooled connection URIs for a Neon database and role without storing them in state. Requires the project to store passwords.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project to retrieve the connection URI for.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "Branch to retrieve the connection URI for. Defaults to the project's default branch.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"endpoint_id": schema.StringAttribute{
+				MarkdownDescription: "Endpoint to retrieve the connection URI for. Defaults to the branch's read-write endpoint.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the database.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"uri": schema.StringAttribute{
+				MarkdownDescription: "Normal connection URI.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"pooled_uri": schema.StringAttribute{
+				MarkdownDescription: "Pooled connection URI.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the role.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *ConnectionURIEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *ConnectionURIEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ConnectionURIEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uri, err := connectionURI(
+		e.client,
+		data.ProjectId.ValueString(),
+		ConnectionURIInput{
+			BranchId:     data.BranchId.ValueStringPointer(),
+			EndpointId:   data.EndpointId.ValueStringPointer(),
+			DatabaseName: data.DatabaseName.ValueString(),
+			RoleName:     data.RoleName.ValueString(),
+			Pooled:       false,
+		},
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get connection URI, got error: %s", err))
+		return
+	}
+
+	pooledURI, err := connectionURI(
+		e.client,
+		data.ProjectId.ValueString(),
+		ConnectionURIInput{
+			BranchId:     data.BranchId.ValueStringPointer(),
+			EndpointId:   data.EndpointId.ValueStringPointer(),
+			DatabaseName: data.DatabaseName.ValueString(),
+			RoleName:     data.RoleName.ValueString(),
+			Pooled:       true,
+		},
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get pooled connection URI, got error: %s", err))
+		return
+	}
+
+	parsed, err := url.Parse(uri.URI)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse connection URI, got error: %s", err))
+		return
+	}
+
+	password, _ := parsed.User.Password()
+
+	tflog.Trace(ctx, "opened a connection uri")
+
+	data.URI = types.StringValue(uri.URI)
+	data.PooledURI = types.StringValue(pooledURI.URI)
+	data.Password = types.StringValue(password)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
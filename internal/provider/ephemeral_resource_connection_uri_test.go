@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccConnectionURIEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConnectionURIEphemeralResourceConfigDefault(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"echo.test", "data.uri",
+						regexp.MustCompile(
+							`^postgresql://budget-app:[^@]*@ep-summer-hill-233691\.us-east-2\.aws\.neon\.tech/budget-app\?sslmode=require$`,
+						),
+					),
+					resource.TestMatchResourceAttr(
+						"echo.test", "data.pooled_uri",
+						regexp.MustCompile(
+							`^postgresql://budget-app:[^@]*@ep-summer-hill-233691-pooler\.us-east-2\.aws\.neon\.tech/budget-app\?sslmode=require$`,
+						),
+					),
+					resource.TestMatchResourceAttr("echo.test", "data.password", existRegex()),
+				),
+			},
+		},
+	})
+}
+
+func testAccConnectionURIEphemeralResourceConfigDefault() string {
+	return `
+ephemeral "neon_connection_uri" "test" {
+  project_id    = "polished-snowflake-328957"
+  database_name = "budget-app"
+  role_name     = "budget-app"
+}
+
+provider "echo" {
+  data = ephemeral.neon_connection_uri.test
+}
+
+resource "echo" "test" {}
+`
+}
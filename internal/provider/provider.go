@@ -7,6 +7,7 @@ import (
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,6 +24,7 @@ func idRegex() *regexp.Regexp {
 }
 
 var _ provider.Provider = &NeonProvider{}
+var _ provider.ProviderWithEphemeralResources = &NeonProvider{}
 
 type NeonProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -87,14 +89,24 @@ func (p *NeonProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 
 	resp.DataSourceData = &client
 	resp.ResourceData = &client
+	resp.EphemeralResourceData = &client
 }
 
 func (p *NeonProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewProjectResource,
 		NewRoleResource,
+		NewRolePasswordResource,
+		NewGrantResource,
+		NewExtensionResource,
+		NewSchemaResource,
+		NewDefaultPrivilegesResource,
+		NewRoleMembershipResource,
+		NewPublicationResource,
+		NewReplicationSlotResource,
 		NewDatabaseResource,
 		NewBranchResource,
+		NewBranchRestoreResource,
 		NewEndpointResource,
 	}
 }
@@ -105,6 +117,12 @@ func (p *NeonProvider) DataSources(ctx context.Context) []func() datasource.Data
 	}
 }
 
+func (p *NeonProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewConnectionURIEphemeralResource,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &NeonProvider{
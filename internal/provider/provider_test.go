@@ -1,11 +1,14 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/jackc/pgx/v5"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -16,8 +19,35 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"neon": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccProtoV6ProviderFactoriesWithEcho also includes the echo provider, which
+// exposes values of ephemeral resources for acceptance testing.
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"neon": providerserver.NewProtocol6WithError(New("test")()),
+	"echo": echoprovider.NewProviderServer(),
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("NEON_TOKEN"); v == "" {
 		t.Fatal("NEON_TOKEN must be set for acceptance tests")
 	}
 }
+
+// testSQLConnect connects to the Postgres given in POSTGRES_URL, which lets the
+// SQL used by the provider be tested against a local container or binary.
+func testSQLConnect(t *testing.T) *pgx.Conn {
+	url := os.Getenv("POSTGRES_URL")
+
+	if url == "" {
+		t.Skip("POSTGRES_URL must be set for SQL tests")
+	}
+
+	conn, err := pgx.Connect(context.Background(), url)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { conn.Close(context.Background()) })
+
+	return conn
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -26,8 +30,153 @@ import (
 
 var ComputeSizeValidator = float64validator.OneOf(0.25, 0.5, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56)
 
+var ComputeProvisionerValidator = stringvalidator.OneOf("k8s-pod", "k8s-neonvm")
+
+const (
+	// Largest compute size which supports autoscaling. Larger computes have a fixed size.
+	maxAutoscalingCu = 16
+	// Largest allowed difference between min_cu and max_cu.
+	maxAutoscalingSpread = 8
+)
+
+// validateComputeSize checks the autoscaling limits of an endpoint against each other. The limits
+// are attributes of the object at parent, which is empty for the resource itself. Null
+// limits take the default of 0.25 and unknown limits are not checked.
+func validateComputeSize(minCu types.Float64, maxCu types.Float64, parent path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if minCu.IsUnknown() || maxCu.IsUnknown() {
+		return diags
+	}
+
+	min := 0.25
+	max := 0.25
+
+	if !minCu.IsNull() {
+		min = minCu.ValueFloat64()
+	}
+
+	if !maxCu.IsNull() {
+		max = maxCu.ValueFloat64()
+	}
+
+	minPath := parent.AtName("min_cu")
+	maxPath := parent.AtName("max_cu")
+
+	switch {
+	case min > max:
+		diags.AddAttributeError(
+			minPath,
+			"Invalid Compute Size",
+			fmt.Sprintf("min_cu (%g) must be less than or equal to max_cu (%g).", min, max),
+		)
+	case max > maxAutoscalingCu && min != max:
+		diags.AddAttributeError(
+			maxPath,
+			"Invalid Compute Size",
+			fmt.Sprintf("Autoscaling is only available up to %d compute units, min_cu (%g) must be equal to max_cu (%g).", maxAutoscalingCu, min, max),
+		)
+	case max-min > maxAutoscalingSpread:
+		diags.AddAttributeError(
+			maxPath,
+			"Invalid Compute Size",
+			fmt.Sprintf("max_cu (%g) can be at most %d compute units more than min_cu (%g).", max, maxAutoscalingSpread, min),
+		)
+	}
+
+	return diags
+}
+
 var _ resource.Resource = &BranchResource{}
 var _ resource.ResourceWithImportState = &BranchResource{}
+var _ resource.ResourceWithValidateConfig = &BranchResource{}
+
+func setAsDefaultOnly() planmodifier.Bool {
+	return setAsDefaultOnlyModifier{}
+}
+
+type setAsDefaultOnlyModifier struct{}
+
+func (m setAsDefaultOnlyModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m setAsDefaultOnlyModifier) MarkdownDescription(_ context.Context) string {
+	return "Cannot be switched from `true` to `false`, another branch must be set as default instead."
+}
+
+func (m setAsDefaultOnlyModifier) PlanModifyBool(_ context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	// Nothing to do on resource creation or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.IsUnknown() || req.PlanValue.ValueBool() || !req.StateValue.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Default Branch Cannot Be Unset",
+		"The branch is the default branch of the project. A branch stops being the default only when another branch is set as default, so set `set_as_default` on that branch instead of switching it off here.",
+	)
+}
+
+func parentIdReplace() planmodifier.String {
+	return parentIdReplaceModifier{}
+}
+
+type parentIdReplaceModifier struct{}
+
+func (m parentIdReplaceModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m parentIdReplaceModifier) MarkdownDescription(_ context.Context) string {
+	return "Changing it recreates the branch only when `allow_recreate` is `true`."
+}
+
+func (m parentIdReplaceModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to do on resource creation or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// If the parent is not configured, we keep the current parent.
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = req.StateValue
+		return
+	}
+
+	if req.PlanValue.IsUnknown() || req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	var allowRecreate types.Bool
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_recreate"), &allowRecreate)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if allowRecreate.ValueBool() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Branch Reparenting Not Supported",
+		fmt.Sprintf(
+			"Neon does not support moving an existing branch to another parent. Changing parent_id from %q to %q "+
+				"needs the branch to be destroyed and created again, which loses all of its data. "+
+				"Set allow_recreate to true to allow this, or revert parent_id.",
+			req.StateValue.ValueString(),
+			req.PlanValue.ValueString(),
+		),
+	)
+}
 
 func NewBranchResource() resource.Resource {
 	return &BranchResource{}
@@ -40,28 +189,59 @@ type BranchResource struct {
 type BranchResourceEndpointModel struct {
 	Id                 types.String  `tfsdk:"id"`
 	Host               types.String  `tfsdk:"host"`
+	PoolerHost         types.String  `tfsdk:"pooler_host"`
+	ProxyHost          types.String  `tfsdk:"proxy_host"`
+	RegionId           types.String  `tfsdk:"region_id"`
+	CreatedAt          types.String  `tfsdk:"created_at"`
+	LastActive         types.String  `tfsdk:"last_active"`
 	MinCu              types.Float64 `tfsdk:"min_cu"`
 	MaxCu              types.Float64 `tfsdk:"max_cu"`
 	ComputeProvisioner types.String  `tfsdk:"compute_provisioner"`
 	SuspendTimeout     types.Int64   `tfsdk:"suspend_timeout"`
+	PgSettings         types.Map     `tfsdk:"pg_settings"`
+	PoolerEnabled      types.Bool    `tfsdk:"pooler_enabled"`
+	PoolerMode         types.String  `tfsdk:"pooler_mode"`
+	Disabled           types.Bool    `tfsdk:"disabled"`
+	DesiredState       types.String  `tfsdk:"desired_state"`
+	CurrentState       types.String  `tfsdk:"current_state"`
 }
 
 var endpointAttrTypes = map[string]attr.Type{
 	"id":                  types.StringType,
 	"host":                types.StringType,
+	"pooler_host":         types.StringType,
+	"proxy_host":          types.StringType,
+	"region_id":           types.StringType,
+	"created_at":          types.StringType,
+	"last_active":         types.StringType,
 	"min_cu":              types.Float64Type,
 	"max_cu":              types.Float64Type,
 	"compute_provisioner": types.StringType,
 	"suspend_timeout":     types.Int64Type,
+	"pg_settings":         types.MapType{ElemType: types.StringType},
+	"pooler_enabled":      types.BoolType,
+	"pooler_mode":         types.StringType,
+	"disabled":            types.BoolType,
+	"desired_state":       types.StringType,
+	"current_state":       types.StringType,
 }
 
 type BranchResourceModel struct {
-	Id        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	ParentId  types.String `tfsdk:"parent_id"`
-	ProjectId types.String `tfsdk:"project_id"`
-	Protected types.Bool   `tfsdk:"protected"`
-	Endpoint  types.Object `tfsdk:"endpoint"`
+	Id            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	ParentId      types.String   `tfsdk:"parent_id"`
+	ProjectId     types.String   `tfsdk:"project_id"`
+	Protected     types.Bool     `tfsdk:"protected"`
+	SetAsDefault  types.Bool     `tfsdk:"set_as_default"`
+	AllowRecreate types.Bool     `tfsdk:"allow_recreate"`
+	CurrentState  types.String   `tfsdk:"current_state"`
+	LogicalSize   types.Int64    `tfsdk:"logical_size"`
+	CreatedAt     types.String   `tfsdk:"created_at"`
+	UpdatedAt     types.String   `tfsdk:"updated_at"`
+	LastResetAt   types.String   `tfsdk:"last_reset_at"`
+	WaitForReady  types.Bool     `tfsdk:"wait_for_ready"`
+	Endpoint      types.Object   `tfsdk:"endpoint"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *BranchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -87,11 +267,11 @@ func (r *BranchResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"parent_id": schema.StringAttribute{
-				MarkdownDescription: "ID of the parent branch. Defaults to the default branch.",
+				MarkdownDescription: "ID of the parent branch. Defaults to the default branch. Neon cannot move a branch to another parent, so changing it fails unless `allow_recreate` is `true`.",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					parentIdReplace(),
 				},
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(idRegex(), "must be an id"),
@@ -113,6 +293,54 @@ func (r *BranchResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"set_as_default": schema.BoolAttribute{
+				MarkdownDescription: "Whether the branch is the default branch of the project. Setting it to `true` makes the branch the default branch. It cannot be switched back to `false`, to move the default elsewhere set it on another branch. The parent branch becomes the default again when the branch is destroyed. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					setAsDefaultOnly(),
+				},
+			},
+			"allow_recreate": schema.BoolAttribute{
+				MarkdownDescription: "Whether the branch can be destroyed and created again when `parent_id` changes. All data of the branch is lost when this happens. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"current_state": schema.StringAttribute{
+				MarkdownDescription: "Current state of the branch.",
+				Computed:            true,
+			},
+			"logical_size": schema.Int64Attribute{
+				MarkdownDescription: "Logical size of the branch in bytes.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the branch was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the branch was last updated.",
+				Computed:            true,
+			},
+			"last_reset_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the branch was last reset from its parent.",
+				Computed:            true,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait for the branch and its endpoint to be ready after they are created. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
 			"endpoint": schema.SingleNestedAttribute{
 				MarkdownDescription: "Read-write compute endpoint settings of the branch.",
 				Optional:            true,
@@ -131,6 +359,38 @@ func (r *BranchResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"pooler_host": schema.StringAttribute{
+						MarkdownDescription: "Pooler host of the endpoint, used for pooled connections.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"proxy_host": schema.StringAttribute{
+						MarkdownDescription: "Proxy host of the region of the endpoint.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"region_id": schema.StringAttribute{
+						MarkdownDescription: "Region of the endpoint.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"created_at": schema.StringAttribute{
+						MarkdownDescription: "Timestamp when the endpoint was created.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"last_active": schema.StringAttribute{
+						MarkdownDescription: "Timestamp when the endpoint was last active.",
+						Computed:            true,
+					},
 					"min_cu": schema.Float64Attribute{
 						MarkdownDescription: "Minimum number of compute units for the endpoint. **Default** `0.25`.",
 						Optional:            true,
@@ -150,8 +410,15 @@ func (r *BranchResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						},
 					},
 					"compute_provisioner": schema.StringAttribute{
-						MarkdownDescription: "Provisioner of the endpoint.",
+						MarkdownDescription: "Provisioner of the endpoint. Either `k8s-pod` or `k8s-neonvm`. Defaults to the provisioner chosen by Neon.",
+						Optional:            true,
 						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+						Validators: []validator.String{
+							ComputeProvisionerValidator,
+						},
 					},
 					"suspend_timeout": schema.Int64Attribute{
 						MarkdownDescription: "Suspend timeout of the endpoint. **Default** `0`.",
@@ -162,12 +429,69 @@ func (r *BranchResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							int64validator.Between(-1, 604800),
 						},
 					},
+					"pooler_enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether connection pooling is enabled for the endpoint. **Default** `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"pooler_mode": schema.StringAttribute{
+						MarkdownDescription: "Connection pooler mode of the endpoint. Either `transaction` or `session`. **Default** `transaction`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("transaction"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("transaction", "session"),
+						},
+					},
+					"pg_settings": schema.MapAttribute{
+						MarkdownDescription: "Postgres settings of the endpoint. Only the settings given here are tracked, settings added by Neon are ignored.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"disabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether connections to the endpoint are blocked. **Default** `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"desired_state": schema.StringAttribute{
+						MarkdownDescription: "State the endpoint is put in on apply. Either `active` or `suspended`. When set, an endpoint that started or suspended on its own is planned to be put back in this state. When not set, the endpoint is left to start and suspend on its own.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("active", "suspended"),
+						},
+					},
+					"current_state": schema.StringAttribute{
+						MarkdownDescription: "Current state of the endpoint.",
+						Computed:            true,
+					},
 				},
 			},
 		},
 	}
 }
 
+func (r *BranchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var endpoint types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("endpoint"), &endpoint)...)
+
+	if resp.Diagnostics.HasError() || endpoint.IsNull() || endpoint.IsUnknown() {
+		return
+	}
+
+	var endpointData *BranchResourceEndpointModel
+
+	resp.Diagnostics.Append(endpoint.As(ctx, &endpointData, basetypes.ObjectAsOptions{})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateComputeSize(endpointData.MinCu, endpointData.MaxCu, path.Root("endpoint"))...)
+}
+
 func (r *BranchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -197,6 +521,17 @@ func (r *BranchResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, 20*time.Minute)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	input := BranchCreateInput{
 		Branch: BranchCreateInputBranch{
 			Name:      data.Name.ValueString(),
@@ -218,10 +553,36 @@ func (r *BranchResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	tflog.Trace(ctx, "created a branch")
 
+	if data.SetAsDefault.ValueBool() {
+		branch, err = branchSetAsDefault(r.client, data.ProjectId.ValueString(), branch.Branch.Id)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set branch as default, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, "set a branch as default")
+	}
+
+	if data.WaitForReady.ValueBool() {
+		branch, err = branchWaitReady(ctx, r.client, data.ProjectId.ValueString(), branch.Branch.Id)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for branch to be ready, got error: %s", err))
+			return
+		}
+	}
+
 	data.Id = types.StringValue(branch.Branch.Id)
 	data.Name = types.StringValue(branch.Branch.Name)
 	data.ProjectId = types.StringValue(branch.Branch.ProjectId)
 	data.Protected = types.BoolValue(branch.Branch.Protected)
+	data.SetAsDefault = types.BoolValue(branch.Branch.Default)
+	data.CurrentState = types.StringValue(branch.Branch.CurrentState)
+	data.LogicalSize = types.Int64Value(branch.Branch.LogicalSize)
+	data.CreatedAt = types.StringValue(branch.Branch.CreatedAt)
+	data.UpdatedAt = types.StringValue(branch.Branch.UpdatedAt)
+	data.LastResetAt = types.StringPointerValue(branch.Branch.LastResetAt)
 
 	if branch.Branch.ParentId != nil {
 		data.ParentId = types.StringValue(*branch.Branch.ParentId)
@@ -246,9 +607,23 @@ func (r *BranchResource) Create(ctx context.Context, req resource.CreateRequest,
 				AutoscalingLimitMinCu: endpointData.MinCu.ValueFloat64(),
 				AutoscalingLimitMaxCu: endpointData.MaxCu.ValueFloat64(),
 				SuspendTimeoutSeconds: endpointData.SuspendTimeout.ValueInt64(),
+				PoolerEnabled:         endpointData.PoolerEnabled.ValueBool(),
+				PoolerMode:            endpointData.PoolerMode.ValueString(),
+				Disabled:              endpointData.Disabled.ValueBool(),
+				Provisioner:           endpointData.ComputeProvisioner.ValueString(),
 			},
 		}
 
+		settings, diags := endpointSettingsInput(ctx, endpointData.PgSettings, types.MapNull(types.StringType))
+
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		input.Endpoint.Settings = settings
+
 		endpoint, err := endpointCreate(r.client, data.ProjectId.ValueString(), input)
 
 		if err != nil {
@@ -258,15 +633,42 @@ func (r *BranchResource) Create(ctx context.Context, req resource.CreateRequest,
 
 		tflog.Trace(ctx, "created an endpoint")
 
+		endpoint, err = endpointApplyDesiredState(r.client, endpoint, endpointData.DesiredState)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change endpoint state of the branch, got error: %s", err))
+			return
+		}
+
+		if data.WaitForReady.ValueBool() {
+			endpoint, err = endpointWaitReady(ctx, r.client, data.ProjectId.ValueString(), endpoint.Endpoint.Id)
+
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for endpoint of the branch to be ready, got error: %s", err))
+				return
+			}
+		}
+
 		data.Endpoint = types.ObjectValueMust(
 			endpointAttrTypes,
 			map[string]attr.Value{
 				"id":                  types.StringValue(endpoint.Endpoint.Id),
 				"host":                types.StringValue(endpoint.Endpoint.Host),
+				"pooler_host":         types.StringValue(endpointPoolerHost(endpoint.Endpoint.Host)),
+				"proxy_host":          types.StringValue(endpoint.Endpoint.ProxyHost),
+				"region_id":           types.StringValue(endpoint.Endpoint.RegionId),
+				"created_at":          types.StringValue(endpoint.Endpoint.CreatedAt),
+				"last_active":         types.StringPointerValue(endpoint.Endpoint.LastActive),
 				"min_cu":              types.Float64Value(endpoint.Endpoint.AutoscalingLimitMinCu),
 				"max_cu":              types.Float64Value(endpoint.Endpoint.AutoscalingLimitMaxCu),
 				"compute_provisioner": types.StringValue(endpoint.Endpoint.ComputeProvisioner),
 				"suspend_timeout":     types.Int64Value(endpoint.Endpoint.SuspendTimeoutSeconds),
+				"pg_settings":         pgSettingsValue(pgSettingsFrom(data.Endpoint), endpoint.Endpoint.Settings.PgSettings),
+				"pooler_enabled":      types.BoolValue(endpoint.Endpoint.PoolerEnabled),
+				"pooler_mode":         types.StringValue(endpoint.Endpoint.PoolerMode),
+				"disabled":            types.BoolValue(endpoint.Endpoint.Disabled),
+				"desired_state":       desiredStateFrom(data.Endpoint),
+				"current_state":       types.StringValue(endpoint.Endpoint.CurrentState),
 			},
 		)
 	}
@@ -301,10 +703,27 @@ func (r *BranchResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	// Name is only missing when importing.
+	importing := data.Name.IsNull()
+
 	data.Id = types.StringValue(branch.Branch.Id)
 	data.Name = types.StringValue(branch.Branch.Name)
 	data.ProjectId = types.StringValue(branch.Branch.ProjectId)
 	data.Protected = types.BoolValue(branch.Branch.Protected)
+	data.SetAsDefault = types.BoolValue(branch.Branch.Default)
+	data.CurrentState = types.StringValue(branch.Branch.CurrentState)
+	data.LogicalSize = types.Int64Value(branch.Branch.LogicalSize)
+	data.CreatedAt = types.StringValue(branch.Branch.CreatedAt)
+	data.UpdatedAt = types.StringValue(branch.Branch.UpdatedAt)
+	data.LastResetAt = types.StringPointerValue(branch.Branch.LastResetAt)
+
+	if data.AllowRecreate.IsNull() {
+		data.AllowRecreate = types.BoolValue(false)
+	}
+
+	if data.WaitForReady.IsNull() {
+		data.WaitForReady = types.BoolValue(false)
+	}
 
 	if branch.Branch.ParentId != nil {
 		data.ParentId = types.StringValue(*branch.Branch.ParentId)
@@ -312,16 +731,29 @@ func (r *BranchResource) Read(ctx context.Context, req resource.ReadRequest, res
 		data.ParentId = types.StringNull()
 	}
 
-	if len(endpoint.Id) > 0 {
+	// The read_write endpoint can also be managed by neon_endpoint, so it is only
+	// tracked here when it already was or when the branch is being imported
+	if len(endpoint.Id) > 0 && (!data.Endpoint.IsNull() || importing) {
 		data.Endpoint = types.ObjectValueMust(
 			endpointAttrTypes,
 			map[string]attr.Value{
 				"id":                  types.StringValue(endpoint.Id),
 				"host":                types.StringValue(endpoint.Host),
+				"pooler_host":         types.StringValue(endpointPoolerHost(endpoint.Host)),
+				"proxy_host":          types.StringValue(endpoint.ProxyHost),
+				"region_id":           types.StringValue(endpoint.RegionId),
+				"created_at":          types.StringValue(endpoint.CreatedAt),
+				"last_active":         types.StringPointerValue(endpoint.LastActive),
 				"min_cu":              types.Float64Value(endpoint.AutoscalingLimitMinCu),
 				"max_cu":              types.Float64Value(endpoint.AutoscalingLimitMaxCu),
 				"compute_provisioner": types.StringValue(endpoint.ComputeProvisioner),
 				"suspend_timeout":     types.Int64Value(endpoint.SuspendTimeoutSeconds),
+				"pg_settings":         pgSettingsValue(pgSettingsFrom(data.Endpoint), endpoint.Settings.PgSettings),
+				"pooler_enabled":      types.BoolValue(endpoint.PoolerEnabled),
+				"pooler_mode":         types.StringValue(endpoint.PoolerMode),
+				"disabled":            types.BoolValue(endpoint.Disabled),
+				"desired_state":       endpointDesiredState(desiredStateFrom(data.Endpoint), endpoint.CurrentState),
+				"current_state":       types.StringValue(endpoint.CurrentState),
 			},
 		)
 	}
@@ -345,13 +777,26 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	var branch = Branch{
-		Id:        state.Id.ValueString(),
-		Name:      state.Name.ValueString(),
-		ParentId:  state.ParentId.ValueStringPointer(),
-		ProjectId: state.ProjectId.ValueString(),
+	updateTimeout, diags := data.Timeouts.Update(ctx, 20*time.Minute)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	current, err := branchGet(r.client, state.ProjectId.ValueString(), state.Id.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read branch, got error: %s", err))
+		return
 	}
 
+	branch := current.Branch
+
 	branchInput := BranchUpdateInput{
 		Branch: BranchUpdateInputBranch{},
 	}
@@ -378,10 +823,31 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 		branch = branchOutput.Branch
 	}
 
+	// A branch stops being the default only when another branch is set as default, which
+	// setAsDefaultOnly enforces at plan time
+	if data.SetAsDefault.ValueBool() && !state.SetAsDefault.ValueBool() {
+		branchOutput, err := branchSetAsDefault(r.client, data.ProjectId.ValueString(), data.Id.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set branch as default, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, "set a branch as default")
+
+		branch = branchOutput.Branch
+	}
+
 	data.Id = types.StringValue(branch.Id)
 	data.Name = types.StringValue(branch.Name)
 	data.ProjectId = types.StringValue(branch.ProjectId)
 	data.Protected = types.BoolValue(branch.Protected)
+	data.SetAsDefault = types.BoolValue(branch.Default)
+	data.CurrentState = types.StringValue(branch.CurrentState)
+	data.LogicalSize = types.Int64Value(branch.LogicalSize)
+	data.CreatedAt = types.StringValue(branch.CreatedAt)
+	data.UpdatedAt = types.StringValue(branch.UpdatedAt)
+	data.LastResetAt = types.StringPointerValue(branch.LastResetAt)
 
 	if branch.ParentId != nil {
 		data.ParentId = types.StringValue(*branch.ParentId)
@@ -401,6 +867,23 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 			return
 		}
 
+		existing, err := branchEndpoint(r.client, data.ProjectId.ValueString(), branch.Id, false)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read endpoint of the branch, got error: %s", err))
+			return
+		}
+
+		if len(existing.Id) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Conflicting Endpoint",
+				fmt.Sprintf("Branch %s already has the read_write endpoint %s, which might be managed by neon_endpoint. Remove one of them.", branch.Id, existing.Id),
+			)
+
+			return
+		}
+
 		input := EndpointCreateInput{
 			Endpoint: EndpointCreateInputEndpoint{
 				BranchId:              branch.Id,
@@ -408,9 +891,23 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 				AutoscalingLimitMinCu: endpointData.MinCu.ValueFloat64(),
 				AutoscalingLimitMaxCu: endpointData.MaxCu.ValueFloat64(),
 				SuspendTimeoutSeconds: endpointData.SuspendTimeout.ValueInt64(),
+				PoolerEnabled:         endpointData.PoolerEnabled.ValueBool(),
+				PoolerMode:            endpointData.PoolerMode.ValueString(),
+				Disabled:              endpointData.Disabled.ValueBool(),
+				Provisioner:           endpointData.ComputeProvisioner.ValueString(),
 			},
 		}
 
+		settings, diags := endpointSettingsInput(ctx, endpointData.PgSettings, types.MapNull(types.StringType))
+
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		input.Endpoint.Settings = settings
+
 		endpointOutput, err := endpointCreate(r.client, data.ProjectId.ValueString(), input)
 
 		if err != nil {
@@ -420,6 +917,22 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 		tflog.Trace(ctx, "created an endpoint")
 
+		endpointOutput, err = endpointApplyDesiredState(r.client, endpointOutput, endpointData.DesiredState)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change endpoint state of the branch, got error: %s", err))
+			return
+		}
+
+		if data.WaitForReady.ValueBool() {
+			endpointOutput, err = endpointWaitReady(ctx, r.client, data.ProjectId.ValueString(), endpointOutput.Endpoint.Id)
+
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for endpoint of the branch to be ready, got error: %s", err))
+				return
+			}
+		}
+
 		endpoint = endpointOutput.Endpoint
 	}
 
@@ -438,9 +951,23 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 				AutoscalingLimitMinCu: endpointData.MinCu.ValueFloat64(),
 				AutoscalingLimitMaxCu: endpointData.MaxCu.ValueFloat64(),
 				SuspendTimeoutSeconds: endpointData.SuspendTimeout.ValueInt64(),
+				PoolerEnabled:         endpointData.PoolerEnabled.ValueBool(),
+				PoolerMode:            endpointData.PoolerMode.ValueString(),
+				Disabled:              endpointData.Disabled.ValueBool(),
+				Provisioner:           endpointData.ComputeProvisioner.ValueString(),
 			},
 		}
 
+		settings, diags := endpointSettingsInput(ctx, endpointData.PgSettings, pgSettingsFrom(state.Endpoint))
+
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		input.Endpoint.Settings = settings
+
 		endpointOuput, err := endpointUpdate(r.client, data.ProjectId.ValueString(), endpointData.Id.ValueString(), input)
 
 		if err != nil {
@@ -450,6 +977,13 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 		tflog.Trace(ctx, "updated an endpoint")
 
+		endpointOuput, err = endpointApplyDesiredState(r.client, endpointOuput, endpointData.DesiredState)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change endpoint state of the branch, got error: %s", err))
+			return
+		}
+
 		endpoint = endpointOuput.Endpoint
 	}
 
@@ -479,10 +1013,21 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 			map[string]attr.Value{
 				"id":                  types.StringValue(endpoint.Id),
 				"host":                types.StringValue(endpoint.Host),
+				"pooler_host":         types.StringValue(endpointPoolerHost(endpoint.Host)),
+				"proxy_host":          types.StringValue(endpoint.ProxyHost),
+				"region_id":           types.StringValue(endpoint.RegionId),
+				"created_at":          types.StringValue(endpoint.CreatedAt),
+				"last_active":         types.StringPointerValue(endpoint.LastActive),
 				"min_cu":              types.Float64Value(endpoint.AutoscalingLimitMinCu),
 				"max_cu":              types.Float64Value(endpoint.AutoscalingLimitMaxCu),
 				"compute_provisioner": types.StringValue(endpoint.ComputeProvisioner),
 				"suspend_timeout":     types.Int64Value(endpoint.SuspendTimeoutSeconds),
+				"pg_settings":         pgSettingsValue(pgSettingsFrom(data.Endpoint), endpoint.Settings.PgSettings),
+				"pooler_enabled":      types.BoolValue(endpoint.PoolerEnabled),
+				"pooler_mode":         types.StringValue(endpoint.PoolerMode),
+				"disabled":            types.BoolValue(endpoint.Disabled),
+				"desired_state":       desiredStateFrom(data.Endpoint),
+				"current_state":       types.StringValue(endpoint.CurrentState),
 			},
 		)
 	} else {
@@ -501,6 +1046,18 @@ func (r *BranchResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Default branch cannot be deleted, so hand the default back to the parent branch
+	if data.SetAsDefault.ValueBool() && !data.ParentId.IsNull() {
+		_, err := branchSetAsDefault(r.client, data.ProjectId.ValueString(), data.ParentId.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set parent branch as default, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, "set a branch as default")
+	}
+
 	err := branchDelete(r.client, data.ProjectId.ValueString(), data.Id.ValueString())
 
 	if err != nil {
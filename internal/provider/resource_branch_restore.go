@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &BranchRestoreResource{}
+var _ resource.ResourceWithValidateConfig = &BranchRestoreResource{}
+
+func NewBranchRestoreResource() resource.Resource {
+	return &BranchRestoreResource{}
+}
+
+type BranchRestoreResource struct {
+	client *http.Client
+}
+
+type BranchRestoreResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	BranchId          types.String `tfsdk:"branch_id"`
+	ProjectId         types.String `tfsdk:"project_id"`
+	SourceBranchId    types.String `tfsdk:"source_branch_id"`
+	SourceLsn         types.String `tfsdk:"source_lsn"`
+	SourceTimestamp   types.String `tfsdk:"source_timestamp"`
+	PreserveUnderName types.String `tfsdk:"preserve_under_name"`
+	PreservedBranchId types.String `tfsdk:"preserved_branch_id"`
+}
+
+func (r *BranchRestoreResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_branch_restore"
+}
+
+func (r *BranchRestoreResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Restores a Neon branch from a source branch. The restore runs when the resource is created and whenever any of its arguments change. Destroying the resource does not undo the restore.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the restore.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "Branch to restore.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project the branch belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"source_branch_id": schema.StringAttribute{
+				MarkdownDescription: "Branch to restore the data from. Use the same branch as `branch_id` to restore it to an earlier point in its own history.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"source_lsn": schema.StringAttribute{
+				MarkdownDescription: "LSN of the source branch to restore the data from. Defaults to the head of the source branch.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(lsnRegex(), "must be an lsn"),
+					stringvalidator.ConflictsWith(path.MatchRoot("source_timestamp")),
+				},
+			},
+			"source_timestamp": schema.StringAttribute{
+				MarkdownDescription: "Timestamp of the source branch to restore the data from in RFC 3339 format. Defaults to the head of the source branch.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(timestampRegex(), "must be an RFC 3339 timestamp"),
+				},
+			},
+			"preserve_under_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the branch that keeps the data of the branch from before the restore. Required when restoring a branch from its own history.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"preserved_branch_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the branch that keeps the data of the branch from before the restore.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BranchRestoreResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *BranchRestoreResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.BranchId.IsUnknown() || data.SourceBranchId.IsUnknown() || data.PreserveUnderName.IsUnknown() {
+		return
+	}
+
+	if data.BranchId.Equal(data.SourceBranchId) && data.PreserveUnderName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preserve_under_name"),
+			"Invalid Branch Restore",
+			"`preserve_under_name` is required when restoring a branch from its own history.",
+		)
+	}
+}
+
+func (r *BranchRestoreResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BranchRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *BranchRestoreResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := BranchRestoreInput{
+		SourceBranchId:    data.SourceBranchId.ValueString(),
+		SourceLsn:         data.SourceLsn.ValueStringPointer(),
+		SourceTimestamp:   data.SourceTimestamp.ValueStringPointer(),
+		PreserveUnderName: data.PreserveUnderName.ValueStringPointer(),
+	}
+
+	branch, err := branchRestore(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), input)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore branch, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "restored a branch")
+
+	// The restore runs as an operation, so wait for it before looking for the preserved branch
+	err = projectWait(r.client, data.ProjectId.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for branch restore, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(fmt.Sprintf("%s:%s", branch.Branch.Id, data.SourceBranchId.ValueString()))
+	data.BranchId = types.StringValue(branch.Branch.Id)
+	data.ProjectId = types.StringValue(branch.Branch.ProjectId)
+	data.PreservedBranchId = types.StringNull()
+
+	// Find the branch which has the data from before the restore
+	if !data.PreserveUnderName.IsNull() {
+		preserved, err := branchByName(r.client, data.ProjectId.ValueString(), data.PreserveUnderName.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read preserved branch, got error: %s", err))
+			return
+		}
+
+		data.PreservedBranchId = types.StringValue(preserved.Id)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BranchRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *BranchRestoreResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	branch, err := branchGet(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString())
+
+	if isNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read branch, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "read a branch restore")
+
+	data.BranchId = types.StringValue(branch.Branch.Id)
+	data.ProjectId = types.StringValue(branch.Branch.ProjectId)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BranchRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state *BranchRestoreResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BranchRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A restore cannot be undone. The preserved branch, if any, is left as it is.
+	tflog.Trace(ctx, "removed a branch restore")
+}
+
+func lsnRegex() *regexp.Regexp {
+	return regexp.MustCompile("^[0-9A-Fa-f]{1,8}/[0-9A-Fa-f]{1,8}$")
+}
+
+func timestampRegex() *regexp.Regexp {
+	return regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBranchRestoreResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBranchRestoreResourceConfigDefault(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_branch_restore.test", "branch_id", idRegex()),
+					resource.TestCheckResourceAttr("neon_branch_restore.test", "project_id", "polished-snowflake-328957"),
+					resource.TestCheckResourceAttr("neon_branch_restore.test", "source_branch_id", "br-patient-mode-718259"),
+					resource.TestCheckNoResourceAttr("neon_branch_restore.test", "source_lsn"),
+					resource.TestCheckNoResourceAttr("neon_branch_restore.test", "source_timestamp"),
+					resource.TestCheckNoResourceAttr("neon_branch_restore.test", "preserve_under_name"),
+					resource.TestCheckNoResourceAttr("neon_branch_restore.test", "preserved_branch_id"),
+				),
+			},
+			// Update with null values
+			{
+				Config: testAccBranchRestoreResourceConfigDefault(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_branch_restore.test", "branch_id", idRegex()),
+					resource.TestCheckResourceAttr("neon_branch_restore.test", "project_id", "polished-snowflake-328957"),
+					resource.TestCheckResourceAttr("neon_branch_restore.test", "source_branch_id", "br-patient-mode-718259"),
+					resource.TestCheckNoResourceAttr("neon_branch_restore.test", "preserved_branch_id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBranchRestoreResourceNonDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBranchRestoreResourceConfigNonDefault("2024-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_branch_restore.test", "branch_id", idRegex()),
+					resource.TestCheckResourceAttr("neon_branch_restore.test", "project_id", "polished-snowflake-328957"),
+					resource.TestCheckResourceAttrPair("neon_branch_restore.test", "source_branch_id", "neon_branch.test", "id"),
+					resource.TestCheckResourceAttr("neon_branch_restore.test", "source_timestamp", "2024-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("neon_branch_restore.test", "preserve_under_name", "analytics-backup"),
+					resource.TestMatchResourceAttr("neon_branch_restore.test", "preserved_branch_id", idRegex()),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBranchRestoreResourceOwnHistoryWithoutPreserve(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBranchRestoreResourceConfigOwnHistory(),
+				ExpectError: regexp.MustCompile("`preserve_under_name` is required"),
+			},
+		},
+	})
+}
+
+func testAccBranchRestoreResourceConfigDefault() string {
+	return `
+resource "neon_branch" "test" {
+  name       = "analytics"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_branch_restore" "test" {
+  branch_id        = neon_branch.test.id
+  project_id       = "polished-snowflake-328957"
+  source_branch_id = "br-patient-mode-718259"
+}
+`
+}
+
+func testAccBranchRestoreResourceConfigNonDefault(timestamp string) string {
+	return fmt.Sprintf(`
+resource "neon_branch" "test" {
+  name       = "analytics"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_branch_restore" "test" {
+  branch_id           = neon_branch.test.id
+  project_id          = "polished-snowflake-328957"
+  source_branch_id    = neon_branch.test.id
+  source_timestamp    = "%s"
+  preserve_under_name = "analytics-backup"
+}
+`, timestamp)
+}
+
+func testAccBranchRestoreResourceConfigOwnHistory() string {
+	return `
+resource "neon_branch_restore" "test" {
+  branch_id        = "br-patient-mode-718259"
+  project_id       = "polished-snowflake-328957"
+  source_branch_id = "br-patient-mode-718259"
+  source_timestamp = "2024-01-01T00:00:00Z"
+}
+`
+}
@@ -2,12 +2,58 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
+func TestValidateComputeSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		minCu types.Float64
+		maxCu types.Float64
+		path  string
+	}{
+		{"defaults", types.Float64Null(), types.Float64Null(), ""},
+		{"unknown", types.Float64Unknown(), types.Float64Value(0.25), ""},
+		{"autoscaling", types.Float64Value(1), types.Float64Value(8), ""},
+		{"max spread", types.Float64Value(8), types.Float64Value(16), ""},
+		{"fixed above autoscaling", types.Float64Value(32), types.Float64Value(32), ""},
+		{"min above max", types.Float64Value(2), types.Float64Value(1), "endpoint.min_cu"},
+		{"min above default max", types.Float64Value(1), types.Float64Null(), "endpoint.min_cu"},
+		{"autoscaling above limit", types.Float64Value(16), types.Float64Value(32), "endpoint.max_cu"},
+		{"spread above limit", types.Float64Value(0.25), types.Float64Value(9), "endpoint.max_cu"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diags := validateComputeSize(test.minCu, test.maxCu, path.Root("endpoint"))
+
+			if test.path == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected errors: %v", diags)
+				}
+
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got: %v", diags)
+			}
+
+			withPath, ok := diags[0].(interface{ Path() path.Path })
+
+			if !ok || withPath.Path().String() != test.path {
+				t.Fatalf("expected error on %s, got: %v", test.path, diags)
+			}
+		})
+	}
+}
+
 func TestAccBranchResourceDefault(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -22,6 +68,12 @@ func TestAccBranchResourceDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-patient-mode-718259"),
 					resource.TestCheckResourceAttr("neon_branch.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_branch.test", "protected", "false"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "false"),
+					resource.TestMatchResourceAttr("neon_branch.test", "current_state", regexp.MustCompile("^(init|ready)$")),
+					resource.TestMatchResourceAttr("neon_branch.test", "logical_size", regexp.MustCompile("^[0-9]+$")),
+					resource.TestMatchResourceAttr("neon_branch.test", "created_at", existRegex()),
+					resource.TestMatchResourceAttr("neon_branch.test", "updated_at", existRegex()),
+					resource.TestCheckNoResourceAttr("neon_branch.test", "last_reset_at"),
 					resource.TestCheckNoResourceAttr("neon_branch.test", "endpoint"),
 				),
 			},
@@ -41,6 +93,7 @@ func TestAccBranchResourceDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-patient-mode-718259"),
 					resource.TestCheckResourceAttr("neon_branch.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_branch.test", "protected", "false"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "false"),
 					resource.TestCheckNoResourceAttr("neon_branch.test", "endpoint"),
 				),
 			},
@@ -53,12 +106,19 @@ func TestAccBranchResourceDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-patient-mode-718259"),
 					resource.TestCheckResourceAttr("neon_branch.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_branch.test", "protected", "true"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "false"),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.id", idRegex()),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.host", hostRegex("us-east-2")),
+					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.pooler_host", poolerHostRegex("us-east-2")),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.proxy_host", "us-east-2.aws.neon.tech"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.region_id", "aws-us-east-2"),
+					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.created_at", existRegex()),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.min_cu", "1"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.max_cu", "2"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.suspend_timeout", "3600"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_enabled", "true"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_mode", "session"),
 				),
 			},
 			// ImportState testing
@@ -77,12 +137,15 @@ func TestAccBranchResourceDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-patient-mode-718259"),
 					resource.TestCheckResourceAttr("neon_branch.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_branch.test", "protected", "false"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "false"),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.id", idRegex()),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.host", hostRegex("us-east-2")),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.min_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.max_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.suspend_timeout", "0"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_enabled", "false"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_mode", "transaction"),
 				),
 			},
 			// ImportState testing
@@ -111,12 +174,15 @@ func TestAccBranchResourceNonDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-proud-heart-a5e356v0"),
 					resource.TestCheckResourceAttr("neon_branch.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_branch.test", "protected", "true"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "false"),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.id", idRegex()),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.host", hostRegex("us-east-2")),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.min_cu", "1"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.max_cu", "2"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.suspend_timeout", "3600"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_enabled", "true"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_mode", "session"),
 				),
 			},
 			// ImportState testing
@@ -135,12 +201,15 @@ func TestAccBranchResourceNonDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-proud-heart-a5e356v0"),
 					resource.TestCheckResourceAttr("neon_branch.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_branch.test", "protected", "true"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "false"),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.id", idRegex()),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.host", hostRegex("us-east-2")),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.min_cu", "1"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.max_cu", "2"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.suspend_timeout", "3600"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_enabled", "true"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_mode", "session"),
 				),
 			},
 			// ImportState testing
@@ -159,12 +228,15 @@ func TestAccBranchResourceNonDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-proud-heart-a5e356v0"),
 					resource.TestCheckResourceAttr("neon_branch.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_branch.test", "protected", "false"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "false"),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.id", idRegex()),
 					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.host", hostRegex("us-east-2")),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.min_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.max_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.suspend_timeout", "0"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_enabled", "false"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.pooler_mode", "transaction"),
 				),
 			},
 			// Update with null values
@@ -176,6 +248,7 @@ func TestAccBranchResourceNonDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-proud-heart-a5e356v0"),
 					resource.TestCheckResourceAttr("neon_branch.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_branch.test", "protected", "false"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "false"),
 					resource.TestCheckNoResourceAttr("neon_branch.test", "endpoint"),
 				),
 			},
@@ -191,6 +264,149 @@ func TestAccBranchResourceNonDefault(t *testing.T) {
 	})
 }
 
+func TestAccBranchResourceSetAsDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBranchResourceConfigSetAsDefault("main", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_branch.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_branch.test", "name", "analytics"),
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "true"),
+				),
+			},
+			// Configuring the project with the new default branch changes nothing
+			{
+				Config: testAccBranchResourceConfigSetAsDefault("analytics", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_branch.test", "set_as_default", "true"),
+					resource.TestCheckResourceAttrPair("neon_project.test", "branch.id", "neon_branch.test", "id"),
+					resource.TestCheckResourceAttr("neon_project.test", "branch.name", "analytics"),
+				),
+			},
+			// Unsetting the default is rejected
+			{
+				Config:      testAccBranchResourceConfigSetAsDefault("analytics", false),
+				ExpectError: regexp.MustCompile("Default Branch Cannot Be Unset"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_branch.test",
+				ImportState:       true,
+				ImportStateIdFunc: branchImportIdFunc,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBranchResourceReparent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBranchResourceConfigReparent("br-patient-mode-718259", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-patient-mode-718259"),
+					resource.TestCheckResourceAttr("neon_branch.test", "allow_recreate", "false"),
+				),
+			},
+			// Update parent without allowing recreation
+			{
+				Config:      testAccBranchResourceConfigReparent("br-proud-heart-a5e356v0", false),
+				ExpectError: regexp.MustCompile("Branch Reparenting Not Supported"),
+			},
+			// Update parent with recreation allowed
+			{
+				Config: testAccBranchResourceConfigReparent("br-proud-heart-a5e356v0", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_branch.test", "parent_id", "br-proud-heart-a5e356v0"),
+					resource.TestCheckResourceAttr("neon_branch.test", "allow_recreate", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBranchResourceWaitForReady(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBranchResourceConfigWaitForReady(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_branch.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_branch.test", "wait_for_ready", "true"),
+					resource.TestCheckResourceAttr("neon_branch.test", "current_state", "ready"),
+					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.id", idRegex()),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBranchResourceDesiredState(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBranchResourceConfigDesiredState("active", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.id", idRegex()),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.desired_state", "active"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.disabled", "false"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccBranchResourceConfigDesiredState("suspended", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_branch.test", "endpoint.id", idRegex()),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.desired_state", "suspended"),
+					resource.TestCheckResourceAttr("neon_branch.test", "endpoint.disabled", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBranchResourceInvalidComputeSize(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Min larger than max
+			{
+				Config:      testAccBranchResourceConfigComputeSize(4, 1),
+				ExpectError: regexp.MustCompile("must be less than or equal to max_cu"),
+			},
+			// Spread larger than allowed
+			{
+				Config:      testAccBranchResourceConfigComputeSize(0.25, 16),
+				ExpectError: regexp.MustCompile("can be at most 8 compute units more than min_cu"),
+			},
+			// Autoscaling above the largest autoscaling size
+			{
+				Config:      testAccBranchResourceConfigComputeSize(18, 20),
+				ExpectError: regexp.MustCompile("Autoscaling is only available up to 16 compute units"),
+			},
+		},
+	})
+}
+
 func testAccBranchResourceConfigDefault(name string) string {
 	return fmt.Sprintf(`
 resource "neon_branch" "test" {
@@ -234,11 +450,61 @@ resource "neon_branch" "test" {
     min_cu         = 1
     max_cu         = 2
     suspend_timeout = 3600
+    pooler_enabled = true
+    pooler_mode = "session"
   }
 }
 `, name, parentId)
 }
 
+func testAccBranchResourceConfigSetAsDefault(projectBranchName string, setAsDefault bool) string {
+	return fmt.Sprintf(`
+resource "neon_project" "test" {
+  name      = "default-branch"
+  region_id = "aws-us-east-2"
+
+  branch = {
+    name = "%s"
+  }
+}
+
+resource "neon_branch" "test" {
+  name           = "analytics"
+  project_id     = neon_project.test.id
+  set_as_default = %t
+
+  endpoint = {}
+}
+`, projectBranchName, setAsDefault)
+}
+
+func testAccBranchResourceConfigReparent(parentId string, allowRecreate bool) string {
+	return fmt.Sprintf(`
+resource "neon_branch" "test" {
+  name           = "analytics"
+  parent_id      = "%s"
+  project_id     = "polished-snowflake-328957"
+  allow_recreate = %t
+}
+`, parentId, allowRecreate)
+}
+
+func testAccBranchResourceConfigWaitForReady() string {
+	return `
+resource "neon_branch" "test" {
+  name           = "analytics"
+  project_id     = "polished-snowflake-328957"
+  wait_for_ready = true
+
+  endpoint = {}
+
+  timeouts = {
+    create = "10m"
+  }
+}
+`
+}
+
 func branchImportIdFunc(state *terraform.State) (string, error) {
 	rawState, ok := state.RootModule().Resources["neon_branch.test"]
 
@@ -248,3 +514,31 @@ func branchImportIdFunc(state *terraform.State) (string, error) {
 
 	return fmt.Sprintf("%s:%s", rawState.Primary.Attributes["project_id"], rawState.Primary.Attributes["id"]), nil
 }
+
+func testAccBranchResourceConfigDesiredState(desiredState string, disabled bool) string {
+	return fmt.Sprintf(`
+resource "neon_branch" "test" {
+  name       = "analytics"
+  project_id = "polished-snowflake-328957"
+
+  endpoint = {
+    desired_state = "%s"
+    disabled      = %t
+  }
+}
+`, desiredState, disabled)
+}
+
+func testAccBranchResourceConfigComputeSize(minCu float64, maxCu float64) string {
+	return fmt.Sprintf(`
+resource "neon_branch" "test" {
+  name       = "analytics"
+  project_id = "polished-snowflake-328957"
+
+  endpoint = {
+    min_cu = %g
+    max_cu = %g
+  }
+}
+`, minCu, maxCu)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -30,11 +32,17 @@ type DatabaseResource struct {
 }
 
 type DatabaseResourceModel struct {
-	Id        types.Int64  `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	OwnerName types.String `tfsdk:"owner_name"`
-	BranchId  types.String `tfsdk:"branch_id"`
-	ProjectId types.String `tfsdk:"project_id"`
+	Id             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	OwnerName      types.String `tfsdk:"owner_name"`
+	BranchId       types.String `tfsdk:"branch_id"`
+	ProjectId      types.String `tfsdk:"project_id"`
+	Encoding       types.String `tfsdk:"encoding"`
+	LcCollate      types.String `tfsdk:"lc_collate"`
+	LcCtype        types.String `tfsdk:"lc_ctype"`
+	LocaleProvider types.String `tfsdk:"locale_provider"`
+	IcuLocale      types.String `tfsdk:"icu_locale"`
+	Template       types.String `tfsdk:"template"`
 }
 
 func (r *DatabaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,7 +51,7 @@ func (r *DatabaseResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Neon database.",
+		MarkdownDescription: "Neon database. Databases with any of the locale options are created over SQL as `owner_name`, which needs the project to store passwords and another database in the branch to connect to. The locale options are read back over SQL, except `template` which Postgres does not keep. Imported databases read their locale options when `owner_name` can connect to them.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				MarkdownDescription: "ID of the database.",
@@ -86,6 +94,79 @@ func (r *DatabaseResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringvalidator.RegexMatches(idRegex(), "must be an id"),
 				},
 			},
+			"encoding": schema.StringAttribute{
+				MarkdownDescription: "Character set encoding of the database, for example `UTF8`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"lc_collate": schema.StringAttribute{
+				MarkdownDescription: "Collation order of the database, for example `en_US.UTF-8`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"lc_ctype": schema.StringAttribute{
+				MarkdownDescription: "Character classification of the database, for example `en_US.UTF-8`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"locale_provider": schema.StringAttribute{
+				MarkdownDescription: "Locale provider of the database. **Options:** `libc`, `icu`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("libc", "icu"),
+				},
+			},
+			"icu_locale": schema.StringAttribute{
+				MarkdownDescription: "ICU locale of the database, for example `und-x-icu`. Requires `locale_provider` to be `icu`. Use the form Postgres reports, as it may canonicalize the value.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("locale_provider")),
+				},
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "Template the database is created from. Defaults to `template0` when any of the locale options are set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -126,14 +207,21 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	input := DatabaseCreateInput{
-		Database: DatabaseCreateInputDatabase{
-			Name:      data.Name.ValueString(),
-			OwnerName: data.OwnerName.ValueString(),
-		},
-	}
+	var database DatabaseOutput
+	var locale *DatabaseLocale
+
+	if databaseHasLocale(data) {
+		database, locale, err = r.createWithLocale(ctx, data)
+	} else {
+		input := DatabaseCreateInput{
+			Database: DatabaseCreateInputDatabase{
+				Name:      data.Name.ValueString(),
+				OwnerName: data.OwnerName.ValueString(),
+			},
+		}
 
-	database, err := databaseCreate(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), input)
+		database, err = databaseCreate(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), input)
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create database, got error: %s", err))
@@ -148,6 +236,12 @@ func (r *DatabaseResource) Create(ctx context.Context, req resource.CreateReques
 	data.BranchId = types.StringValue(database.Database.BranchId)
 	data.ProjectId = types.StringValue(branch.Branch.ProjectId)
 
+	if locale != nil && data.Template.IsUnknown() {
+		data.Template = types.StringValue("template0")
+	}
+
+	databaseSetLocale(data, locale, true)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -160,6 +254,9 @@ func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	// Only import sets no id
+	importing := data.Id.IsNull()
+
 	branch, err := branchGet(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString())
 
 	if err != nil {
@@ -184,6 +281,22 @@ func (r *DatabaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.BranchId = types.StringValue(database.Database.BranchId)
 	data.ProjectId = types.StringValue(branch.Branch.ProjectId)
 
+	if importing || databaseHasLocale(data) {
+		locale, err := r.readLocale(ctx, data)
+
+		if err != nil && importing {
+			resp.Diagnostics.AddWarning(
+				"Unable to Read Database Locale",
+				fmt.Sprintf("The locale options of database %s are left unset, which replaces the database when they are configured, got error: %s", data.Name.ValueString(), err),
+			)
+		} else if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database locale, got error: %s", err))
+			return
+		}
+
+		databaseSetLocale(data, locale, false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -270,3 +383,150 @@ func (r *DatabaseResource) ImportState(ctx context.Context, req resource.ImportS
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_id"), parts[1])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
 }
+
+// createWithLocale creates the database over SQL while connected to another
+// database of the branch, since the API can not set locale options. It
+// connects to the oldest database owned by `owner_name`, or else to the
+// default database of the branch, which is the oldest one.
+func (r *DatabaseResource) createWithLocale(ctx context.Context, data *DatabaseResourceModel) (DatabaseOutput, *DatabaseLocale, error) {
+	databases, err := databaseList(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString())
+
+	if err != nil {
+		return DatabaseOutput{}, nil, err
+	}
+
+	var target *Database
+
+	for i, database := range databases.Databases {
+		owned := database.OwnerName == data.OwnerName.ValueString()
+
+		if target == nil ||
+			(owned && target.OwnerName != data.OwnerName.ValueString()) ||
+			(owned == (target.OwnerName == data.OwnerName.ValueString()) && database.Id < target.Id) {
+			target = &databases.Databases[i]
+		}
+	}
+
+	if target == nil {
+		return DatabaseOutput{}, nil, fmt.Errorf("branch %s has no database to connect to", data.BranchId.ValueString())
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), target.Name, data.OwnerName.ValueString())
+
+	if err != nil {
+		return DatabaseOutput{}, nil, fmt.Errorf("unable to connect to database %s as %s, which needs the project to store passwords, got error: %w", target.Name, data.OwnerName.ValueString(), err)
+	}
+
+	defer conn.Close(ctx)
+
+	locale := DatabaseLocale{
+		Encoding:       databaseLocaleValue(data.Encoding),
+		LcCollate:      databaseLocaleValue(data.LcCollate),
+		LcCtype:        databaseLocaleValue(data.LcCtype),
+		LocaleProvider: databaseLocaleValue(data.LocaleProvider),
+		IcuLocale:      databaseLocaleValue(data.IcuLocale),
+		Template:       databaseLocaleValue(data.Template),
+	}
+
+	err = databaseCreateWithLocale(ctx, conn, data.Name.ValueString(), data.OwnerName.ValueString(), locale)
+
+	if err != nil {
+		return DatabaseOutput{}, nil, err
+	}
+
+	read, err := databaseLocaleRead(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		return DatabaseOutput{}, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	database, err := databaseWaitVisible(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.Name.ValueString())
+
+	return database, read, err
+}
+
+// readLocale reads the locale options over SQL, connected to the database
+// itself as its owner.
+func (r *DatabaseResource) readLocale(ctx context.Context, data *DatabaseResourceModel) (*DatabaseLocale, error) {
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.Name.ValueString(), "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close(ctx)
+
+	return databaseLocaleRead(ctx, conn, data.Name.ValueString())
+}
+
+// databaseSetLocale sets the locale options read from the database, keeping
+// values that only differ in case, or any known value when keepKnown is set
+// as planned values must not change on create. Options left unknown on a
+// database without locale become null.
+func databaseSetLocale(data *DatabaseResourceModel, locale *DatabaseLocale, keepKnown bool) {
+	if locale == nil {
+		locale = &DatabaseLocale{}
+	}
+
+	set := func(current types.String, value string, equal func(string, string) bool) types.String {
+		known := !current.IsNull() && !current.IsUnknown()
+
+		if known && (keepKnown || equal(current.ValueString(), value)) {
+			return current
+		}
+
+		if value == "" {
+			return types.StringNull()
+		}
+
+		return types.StringValue(value)
+	}
+
+	data.Encoding = set(data.Encoding, locale.Encoding, databaseEncodingEqual)
+	data.LcCollate = set(data.LcCollate, locale.LcCollate, strings.EqualFold)
+	data.LcCtype = set(data.LcCtype, locale.LcCtype, strings.EqualFold)
+	data.LocaleProvider = set(data.LocaleProvider, locale.LocaleProvider, strings.EqualFold)
+	data.IcuLocale = set(data.IcuLocale, locale.IcuLocale, strings.EqualFold)
+
+	// Postgres does not keep the template
+	if data.Template.IsUnknown() {
+		data.Template = types.StringNull()
+	}
+}
+
+// databaseEncodingEqual compares encodings the way Postgres matches their
+// names, ignoring case and anything but letters and digits, so `UTF-8`
+// equals the `UTF8` it reports back.
+func databaseEncodingEqual(a string, b string) bool {
+	normalize := func(encoding string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToUpper(r)
+			}
+
+			return -1
+		}, encoding)
+	}
+
+	return normalize(a) == normalize(b)
+}
+
+func databaseLocaleValue(value types.String) string {
+	if value.IsUnknown() {
+		return ""
+	}
+
+	return value.ValueString()
+}
+
+func databaseHasLocale(data *DatabaseResourceModel) bool {
+	return databaseLocaleValue(data.Encoding) != "" ||
+		databaseLocaleValue(data.LcCollate) != "" ||
+		databaseLocaleValue(data.LcCtype) != "" ||
+		databaseLocaleValue(data.LocaleProvider) != "" ||
+		databaseLocaleValue(data.IcuLocale) != "" ||
+		databaseLocaleValue(data.Template) != ""
+}
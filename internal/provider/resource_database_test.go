@@ -7,6 +7,26 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+func TestDatabaseEncodingEqual(t *testing.T) {
+	tests := []struct {
+		a     string
+		b     string
+		equal bool
+	}{
+		{"UTF8", "UTF8", true},
+		{"utf8", "UTF8", true},
+		{"UTF-8", "UTF8", true},
+		{"iso_8859_5", "ISO_8859_5", true},
+		{"LATIN1", "UTF8", false},
+	}
+
+	for _, test := range tests {
+		if databaseEncodingEqual(test.a, test.b) != test.equal {
+			t.Errorf("databaseEncodingEqual(%q, %q) should be %t", test.a, test.b, test.equal)
+		}
+	}
+}
+
 func TestAccDatabaseResourceDefault(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -29,6 +49,8 @@ func TestAccDatabaseResourceDefault(t *testing.T) {
 				ImportState:       true,
 				ImportStateId:     "polished-snowflake-328957:br-patient-mode-718259:todo-app",
 				ImportStateVerify: true,
+				// Import reads the locale options a database created over the API has no state for
+				ImportStateVerifyIgnore: []string{"encoding", "lc_collate", "lc_ctype", "locale_provider", "icu_locale"},
 			},
 			// Update with null values
 			{
@@ -58,6 +80,8 @@ func TestAccDatabaseResourceDefault(t *testing.T) {
 				ImportState:       true,
 				ImportStateId:     "polished-snowflake-328957:br-patient-mode-718259:nue-todo-app",
 				ImportStateVerify: true,
+				// Import reads the locale options a database created over the API has no state for
+				ImportStateVerifyIgnore: []string{"encoding", "lc_collate", "lc_ctype", "locale_provider", "icu_locale"},
 			},
 			// Delete testing automatically occurs in TestCase
 		},
@@ -74,3 +98,62 @@ resource "neon_database" "test" {
 }
 `, name, owner)
 }
+
+func TestAccDatabaseResourceLocale(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDatabaseResourceConfigLocale("i18n-app"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_database.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_database.test", "name", "i18n-app"),
+					resource.TestCheckResourceAttr("neon_database.test", "owner_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_database.test", "branch_id", "br-patient-mode-718259"),
+					resource.TestCheckResourceAttr("neon_database.test", "project_id", "polished-snowflake-328957"),
+					resource.TestCheckResourceAttr("neon_database.test", "encoding", "UTF8"),
+					resource.TestCheckResourceAttr("neon_database.test", "locale_provider", "icu"),
+					resource.TestCheckResourceAttr("neon_database.test", "icu_locale", "und-x-icu"),
+					resource.TestCheckResourceAttrSet("neon_database.test", "lc_collate"),
+					resource.TestCheckResourceAttr("neon_database.test", "template", "template0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "neon_database.test",
+				ImportState:             true,
+				ImportStateId:           "polished-snowflake-328957:br-patient-mode-718259:i18n-app",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"template"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccDatabaseResourceConfigLocale("nue-i18n-app"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_database.test", "name", "nue-i18n-app"),
+					resource.TestCheckResourceAttr("neon_database.test", "encoding", "UTF8"),
+					resource.TestCheckResourceAttr("neon_database.test", "locale_provider", "icu"),
+					resource.TestCheckResourceAttr("neon_database.test", "icu_locale", "und-x-icu"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccDatabaseResourceConfigLocale(name string) string {
+	return fmt.Sprintf(`
+resource "neon_database" "test" {
+  name = "%s"
+  owner_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+
+  encoding = "UTF8"
+  locale_provider = "icu"
+  icu_locale = "und-x-icu"
+}
+`, name)
+}
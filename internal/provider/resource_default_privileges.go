@@ -0,0 +1,430 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &DefaultPrivilegesResource{}
+var _ resource.ResourceWithValidateConfig = &DefaultPrivilegesResource{}
+
+func NewDefaultPrivilegesResource() resource.Resource {
+	return &DefaultPrivilegesResource{}
+}
+
+type DefaultPrivilegesResource struct {
+	client *http.Client
+}
+
+type DefaultPrivilegesResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Owner           types.String `tfsdk:"owner"`
+	Grantee         types.String `tfsdk:"grantee"`
+	ObjectType      types.String `tfsdk:"object_type"`
+	Schema          types.String `tfsdk:"schema"`
+	Privileges      types.Set    `tfsdk:"privileges"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+	DatabaseName    types.String `tfsdk:"database_name"`
+	RoleName        types.String `tfsdk:"role_name"`
+	BranchId        types.String `tfsdk:"branch_id"`
+	ProjectId       types.String `tfsdk:"project_id"`
+}
+
+func (r *DefaultPrivilegesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_privileges"
+}
+
+func (r *DefaultPrivilegesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Privileges granted on objects created by a role in the future, managed over SQL with `ALTER DEFAULT PRIVILEGES`. The project must store passwords.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the default privileges.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Name of the role creating the objects, for example the role running migrations.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"grantee": schema.StringAttribute{
+				MarkdownDescription: "Name of the role receiving the privileges.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the objects. **Options:** `table`, `sequence`, `function`, `type`, `schema`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("table", "sequence", "function", "type", "schema"),
+				},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "Schema the objects are created in. Applies to objects in all schemas when not set.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"privileges": schema.SetAttribute{
+				MarkdownDescription: "Privileges to grant, for example `SELECT` or `EXECUTE`.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf("CREATE", "USAGE", "EXECUTE", "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"),
+					),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				MarkdownDescription: "Whether the grantee can grant the privileges to others. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Database the default privileges belong to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Role to connect as, which must be `owner` or a member of it. Defaults to the owner of the database.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "Branch the database belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project the database belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+		},
+	}
+}
+
+func (r *DefaultPrivilegesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *DefaultPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ObjectType.IsUnknown() || data.ObjectType.IsNull() {
+		return
+	}
+
+	objectType := data.ObjectType.ValueString()
+
+	if objectType == "schema" && !data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("schema"), "Invalid Default Privileges", "`schema` can not be set when `object_type` is `schema`.")
+	}
+
+	if data.Privileges.IsUnknown() || data.Privileges.IsNull() {
+		return
+	}
+
+	var privileges []types.String
+
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, true)...)
+
+	for _, privilege := range privileges {
+		if privilege.IsUnknown() || privilege.IsNull() {
+			continue
+		}
+
+		if !slices.Contains(defaultPrivilegesPrivileges[objectType], privilege.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("privileges"),
+				"Invalid Default Privileges",
+				fmt.Sprintf("`%s` can not be granted on a %s, valid privileges are %s.", privilege.ValueString(), objectType, strings.Join(defaultPrivilegesPrivileges[objectType], ", ")),
+			)
+		}
+	}
+}
+
+func (r *DefaultPrivilegesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DefaultPrivilegesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *DefaultPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, diags := defaultPrivilegesFrom(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = defaultPrivilegesCreate(ctx, conn, privileges)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create default privileges, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created default privileges")
+
+	data.Id = types.StringValue(defaultPrivilegesId(data))
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultPrivilegesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DefaultPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := defaultPrivilegesFrom(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	privileges, err := defaultPrivilegesRead(ctx, conn, current)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read default privileges, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "read default privileges")
+
+	if len(privileges.Privileges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	held, diags := types.SetValueFrom(ctx, types.StringType, privileges.Privileges)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Privileges = held
+	data.WithGrantOption = types.BoolValue(privileges.WithGrantOption)
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultPrivilegesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *DefaultPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, diags := defaultPrivilegesFrom(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = defaultPrivilegesUpdate(ctx, conn, privileges)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update default privileges, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated default privileges")
+
+	data.Id = types.StringValue(defaultPrivilegesId(data))
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultPrivilegesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *DefaultPrivilegesResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privileges, diags := defaultPrivilegesFrom(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = defaultPrivilegesDelete(ctx, conn, privileges)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete default privileges, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted default privileges")
+}
+
+func defaultPrivilegesFrom(ctx context.Context, data *DefaultPrivilegesResourceModel) (DefaultPrivileges, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	privileges := DefaultPrivileges{
+		Owner:           data.Owner.ValueString(),
+		Role:            data.Grantee.ValueString(),
+		ObjectType:      data.ObjectType.ValueString(),
+		Schema:          data.Schema.ValueString(),
+		WithGrantOption: data.WithGrantOption.ValueBool(),
+	}
+
+	diags.Append(data.Privileges.ElementsAs(ctx, &privileges.Privileges, false)...)
+
+	return privileges, diags
+}
+
+func defaultPrivilegesId(data *DefaultPrivilegesResourceModel) string {
+	return strings.Join(
+		[]string{
+			data.ProjectId.ValueString(),
+			data.BranchId.ValueString(),
+			data.DatabaseName.ValueString(),
+			data.Owner.ValueString(),
+			data.Grantee.ValueString(),
+			data.ObjectType.ValueString(),
+			data.Schema.ValueString(),
+		},
+		":",
+	)
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDefaultPrivilegesResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDefaultPrivilegesResourceConfigDefault(`["SELECT"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "id", "polished-snowflake-328957:br-patient-mode-718259:budget-app:budget-app:reporting:table:public"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "owner", "budget-app"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "grantee", "reporting"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "object_type", "table"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "schema", "public"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("neon_default_privileges.test", "privileges.*", "SELECT"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "with_grant_option", "false"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "database_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "role_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "branch_id", "br-patient-mode-718259"),
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "project_id", "polished-snowflake-328957"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccDefaultPrivilegesResourceConfigDefault(`["SELECT", "UPDATE"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_default_privileges.test", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("neon_default_privileges.test", "privileges.*", "SELECT"),
+					resource.TestCheckTypeSetElemAttr("neon_default_privileges.test", "privileges.*", "UPDATE"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDefaultPrivilegesResourceInvalidSchema(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "neon_default_privileges" "test" {
+  owner = "budget-app"
+  grantee = "reporting"
+  object_type = "schema"
+  schema = "public"
+  privileges = ["USAGE"]
+  database_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+`,
+				ExpectError: regexp.MustCompile("`schema` can not be set when `object_type` is `schema`"),
+			},
+		},
+	})
+}
+
+func testAccDefaultPrivilegesResourceConfigDefault(privileges string) string {
+	return fmt.Sprintf(`
+resource "neon_role" "test" {
+  name = "reporting"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_default_privileges" "test" {
+  owner = "budget-app"
+  grantee = neon_role.test.name
+  object_type = "table"
+  schema = "public"
+  privileges = %s
+  database_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+`, privileges)
+}
@@ -5,15 +5,21 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,6 +28,8 @@ import (
 
 var _ resource.Resource = &EndpointResource{}
 var _ resource.ResourceWithImportState = &EndpointResource{}
+var _ resource.ResourceWithValidateConfig = &EndpointResource{}
+var _ resource.ResourceWithModifyPlan = &EndpointResource{}
 
 func NewEndpointResource() resource.Resource {
 	return &EndpointResource{}
@@ -32,15 +40,29 @@ type EndpointResource struct {
 }
 
 type EndpointResourceModel struct {
-	Id                 types.String  `tfsdk:"id"`
-	BranchId           types.String  `tfsdk:"branch_id"`
-	ProjectId          types.String  `tfsdk:"project_id"`
-	Type               types.String  `tfsdk:"type"`
-	Host               types.String  `tfsdk:"host"`
-	MinCu              types.Float64 `tfsdk:"min_cu"`
-	MaxCu              types.Float64 `tfsdk:"max_cu"`
-	ComputeProvisioner types.String  `tfsdk:"compute_provisioner"`
-	SuspendTimeout     types.Int64   `tfsdk:"suspend_timeout"`
+	Id                 types.String   `tfsdk:"id"`
+	BranchId           types.String   `tfsdk:"branch_id"`
+	ProjectId          types.String   `tfsdk:"project_id"`
+	Type               types.String   `tfsdk:"type"`
+	Host               types.String   `tfsdk:"host"`
+	PoolerHost         types.String   `tfsdk:"pooler_host"`
+	ProxyHost          types.String   `tfsdk:"proxy_host"`
+	RegionId           types.String   `tfsdk:"region_id"`
+	CreatedAt          types.String   `tfsdk:"created_at"`
+	LastActive         types.String   `tfsdk:"last_active"`
+	MinCu              types.Float64  `tfsdk:"min_cu"`
+	MaxCu              types.Float64  `tfsdk:"max_cu"`
+	ComputeProvisioner types.String   `tfsdk:"compute_provisioner"`
+	SuspendTimeout     types.Int64    `tfsdk:"suspend_timeout"`
+	PgSettings         types.Map      `tfsdk:"pg_settings"`
+	PoolerEnabled      types.Bool     `tfsdk:"pooler_enabled"`
+	PoolerMode         types.String   `tfsdk:"pooler_mode"`
+	Disabled           types.Bool     `tfsdk:"disabled"`
+	DesiredState       types.String   `tfsdk:"desired_state"`
+	CurrentState       types.String   `tfsdk:"current_state"`
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+	WaitForReady       types.Bool     `tfsdk:"wait_for_ready"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *EndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,7 +71,7 @@ func (r *EndpointResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Neon endpoint. This creates `read_only` endpoints. Please use `neon_branch` to create the `read_write` endpoint.",
+		MarkdownDescription: "Neon endpoint. A branch can have any number of `read_only` endpoints and one `read_write` endpoint. Manage the `read_write` endpoint either here or in the `endpoint` of `neon_branch`, not both.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Identifier of the endpoint.",
@@ -79,10 +101,11 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "Type of the endpoint.",
+				MarkdownDescription: "Type of the endpoint. Either `read_only` or `read_write`. **Default** `read_only`. Set it after importing a `read_write` endpoint, otherwise the endpoint is replaced.",
+				Optional:            true,
 				Computed:            true,
+				Default:             stringdefault.StaticString("read_only"),
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
@@ -96,6 +119,38 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"pooler_host": schema.StringAttribute{
+				MarkdownDescription: "Pooler host of the endpoint, used for pooled connections.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"proxy_host": schema.StringAttribute{
+				MarkdownDescription: "Proxy host of the region of the endpoint.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region_id": schema.StringAttribute{
+				MarkdownDescription: "Region of the endpoint.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the endpoint was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_active": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the endpoint was last active.",
+				Computed:            true,
+			},
 			"min_cu": schema.Float64Attribute{
 				MarkdownDescription: "Minimum number of compute units for the endpoint. **Default** `0.25`.",
 				Optional:            true,
@@ -115,8 +170,15 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"compute_provisioner": schema.StringAttribute{
-				MarkdownDescription: "Provisioner of the endpoint.",
+				MarkdownDescription: "Provisioner of the endpoint. Either `k8s-pod` or `k8s-neonvm`. Defaults to the provisioner chosen by Neon.",
+				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					ComputeProvisionerValidator,
+				},
 			},
 			"suspend_timeout": schema.Int64Attribute{
 				MarkdownDescription: "Suspend timeout of the endpoint. **Default** `0`.",
@@ -127,10 +189,105 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 					int64validator.Between(-1, 604800),
 				},
 			},
+			"pooler_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether connection pooling is enabled for the endpoint. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"pooler_mode": schema.StringAttribute{
+				MarkdownDescription: "Connection pooler mode of the endpoint. Either `transaction` or `session`. **Default** `transaction`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("transaction"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("transaction", "session"),
+				},
+			},
+			"pg_settings": schema.MapAttribute{
+				MarkdownDescription: "Postgres settings of the endpoint. Only the settings given here are tracked, settings added by Neon are ignored.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether connections to the endpoint are blocked. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"desired_state": schema.StringAttribute{
+				MarkdownDescription: "State the endpoint is put in on apply. Either `active` or `suspended`. When set, an endpoint that started or suspended on its own is planned to be put back in this state. When not set, the endpoint is left to start and suspend on its own.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("active", "suspended"),
+				},
+			},
+			"current_state": schema.StringAttribute{
+				MarkdownDescription: "Current state of the endpoint.",
+				Computed:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over the `read_write` endpoint of the branch when it already exists instead of failing. The endpoint is deleted when this resource is destroyed, even when it was created by `neon_branch`, so remove it from the `endpoint` of `neon_branch` first. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait for the endpoint to be ready after it is created. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+			}),
 		},
 	}
 }
 
+func (r *EndpointResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *EndpointResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateComputeSize(data.MinCu, data.MaxCu, path.Empty())...)
+}
+
+func (r *EndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only creating an endpoint can adopt one.
+	if !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data *EndpointResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.AdoptExisting.ValueBool() || data.Type.ValueString() != "read_write" || data.ProjectId.IsUnknown() || data.BranchId.IsUnknown() {
+		return
+	}
+
+	endpoint, err := branchEndpoint(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), false)
+
+	if err != nil || len(endpoint.Id) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("adopt_existing"),
+		"Adopting Existing Endpoint",
+		fmt.Sprintf("The read_write endpoint %s of branch %s is taken over and deleted when this resource is destroyed. If it was created by neon_branch, remove it from the endpoint of neon_branch so that it is not managed twice.", endpoint.Id, data.BranchId.ValueString()),
+	)
+}
+
 func (r *EndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -160,34 +317,133 @@ func (r *EndpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, 20*time.Minute)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var existing Endpoint
+
+	if data.Type.ValueString() == "read_write" {
+		endpoint, err := branchEndpoint(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), false)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read endpoint of the branch, got error: %s", err))
+			return
+		}
+
+		if len(endpoint.Id) > 0 && !data.AdoptExisting.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type"),
+				"Conflicting Endpoint",
+				fmt.Sprintf("Branch %s already has the read_write endpoint %s. Remove the endpoint from neon_branch, import it or set adopt_existing to take it over.", data.BranchId.ValueString(), endpoint.Id),
+			)
+
+			return
+		}
+
+		existing = endpoint
+	}
+
 	input := EndpointCreateInput{
 		Endpoint: EndpointCreateInputEndpoint{
 			BranchId:              data.BranchId.ValueString(),
-			Type:                  "read_only",
+			Type:                  data.Type.ValueString(),
 			AutoscalingLimitMinCu: data.MinCu.ValueFloat64(),
 			AutoscalingLimitMaxCu: data.MaxCu.ValueFloat64(),
 			SuspendTimeoutSeconds: data.SuspendTimeout.ValueInt64(),
+			PoolerEnabled:         data.PoolerEnabled.ValueBool(),
+			PoolerMode:            data.PoolerMode.ValueString(),
+			Disabled:              data.Disabled.ValueBool(),
+			Provisioner:           data.ComputeProvisioner.ValueString(),
 		},
 	}
 
-	endpoint, err := endpointCreate(r.client, data.ProjectId.ValueString(), input)
+	settings, diags := endpointSettingsInput(ctx, data.PgSettings, types.MapNull(types.StringType))
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input.Endpoint.Settings = settings
+
+	var endpoint EndpointOutput
+	var err error
+
+	if len(existing.Id) > 0 {
+		endpoint, err = endpointUpdate(r.client, data.ProjectId.ValueString(), existing.Id, EndpointUpdateInput{
+			Endpoint: EndpointUpdateInputEndpoint{
+				AutoscalingLimitMinCu: input.Endpoint.AutoscalingLimitMinCu,
+				AutoscalingLimitMaxCu: input.Endpoint.AutoscalingLimitMaxCu,
+				SuspendTimeoutSeconds: input.Endpoint.SuspendTimeoutSeconds,
+				Settings:              input.Endpoint.Settings,
+				PoolerEnabled:         input.Endpoint.PoolerEnabled,
+				PoolerMode:            input.Endpoint.PoolerMode,
+				Disabled:              input.Endpoint.Disabled,
+				Provisioner:           input.Endpoint.Provisioner,
+			},
+		})
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to adopt endpoint, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, "adopted a endpoint")
+	} else {
+		endpoint, err = endpointCreate(r.client, data.ProjectId.ValueString(), input)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create endpoint, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, "created a endpoint")
+	}
+
+	endpoint, err = endpointApplyDesiredState(r.client, endpoint, data.DesiredState)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create endpoint, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change endpoint state, got error: %s", err))
 		return
 	}
 
-	tflog.Trace(ctx, "created a endpoint")
+	if data.WaitForReady.ValueBool() {
+		endpoint, err = endpointWaitReady(ctx, r.client, data.ProjectId.ValueString(), endpoint.Endpoint.Id)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for endpoint to be ready, got error: %s", err))
+			return
+		}
+	}
 
 	data.Id = types.StringValue(endpoint.Endpoint.Id)
 	data.BranchId = types.StringValue(endpoint.Endpoint.BranchId)
 	data.ProjectId = types.StringValue(endpoint.Endpoint.ProjectId)
 	data.Type = types.StringValue(endpoint.Endpoint.Type)
 	data.Host = types.StringValue(endpoint.Endpoint.Host)
+	data.PoolerHost = types.StringValue(endpointPoolerHost(endpoint.Endpoint.Host))
+	data.ProxyHost = types.StringValue(endpoint.Endpoint.ProxyHost)
+	data.RegionId = types.StringValue(endpoint.Endpoint.RegionId)
+	data.CreatedAt = types.StringValue(endpoint.Endpoint.CreatedAt)
+	data.LastActive = types.StringPointerValue(endpoint.Endpoint.LastActive)
 	data.MinCu = types.Float64Value(endpoint.Endpoint.AutoscalingLimitMinCu)
 	data.MaxCu = types.Float64Value(endpoint.Endpoint.AutoscalingLimitMaxCu)
 	data.ComputeProvisioner = types.StringValue(endpoint.Endpoint.ComputeProvisioner)
 	data.SuspendTimeout = types.Int64Value(endpoint.Endpoint.SuspendTimeoutSeconds)
+	data.PgSettings = pgSettingsValue(data.PgSettings, endpoint.Endpoint.Settings.PgSettings)
+	data.PoolerEnabled = types.BoolValue(endpoint.Endpoint.PoolerEnabled)
+	data.PoolerMode = types.StringValue(endpoint.Endpoint.PoolerMode)
+	data.Disabled = types.BoolValue(endpoint.Endpoint.Disabled)
+	data.CurrentState = types.StringValue(endpoint.Endpoint.CurrentState)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -212,21 +468,41 @@ func (r *EndpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	tflog.Trace(ctx, "read a endpoint")
 
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+
+	if data.WaitForReady.IsNull() {
+		data.WaitForReady = types.BoolValue(false)
+	}
+
 	data.Id = types.StringValue(endpoint.Endpoint.Id)
 	data.BranchId = types.StringValue(endpoint.Endpoint.BranchId)
 	data.ProjectId = types.StringValue(endpoint.Endpoint.ProjectId)
 	data.Type = types.StringValue(endpoint.Endpoint.Type)
 	data.Host = types.StringValue(endpoint.Endpoint.Host)
+	data.PoolerHost = types.StringValue(endpointPoolerHost(endpoint.Endpoint.Host))
+	data.ProxyHost = types.StringValue(endpoint.Endpoint.ProxyHost)
+	data.RegionId = types.StringValue(endpoint.Endpoint.RegionId)
+	data.CreatedAt = types.StringValue(endpoint.Endpoint.CreatedAt)
+	data.LastActive = types.StringPointerValue(endpoint.Endpoint.LastActive)
 	data.MinCu = types.Float64Value(endpoint.Endpoint.AutoscalingLimitMinCu)
 	data.MaxCu = types.Float64Value(endpoint.Endpoint.AutoscalingLimitMaxCu)
 	data.ComputeProvisioner = types.StringValue(endpoint.Endpoint.ComputeProvisioner)
 	data.SuspendTimeout = types.Int64Value(endpoint.Endpoint.SuspendTimeoutSeconds)
+	data.PgSettings = pgSettingsValue(data.PgSettings, endpoint.Endpoint.Settings.PgSettings)
+	data.PoolerEnabled = types.BoolValue(endpoint.Endpoint.PoolerEnabled)
+	data.PoolerMode = types.StringValue(endpoint.Endpoint.PoolerMode)
+	data.Disabled = types.BoolValue(endpoint.Endpoint.Disabled)
+	data.DesiredState = endpointDesiredState(data.DesiredState, endpoint.Endpoint.CurrentState)
+	data.CurrentState = types.StringValue(endpoint.Endpoint.CurrentState)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data *EndpointResourceModel
+	var state *EndpointResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -234,14 +510,34 @@ func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	input := EndpointUpdateInput{
 		Endpoint: EndpointUpdateInputEndpoint{
 			AutoscalingLimitMinCu: data.MinCu.ValueFloat64(),
 			AutoscalingLimitMaxCu: data.MaxCu.ValueFloat64(),
 			SuspendTimeoutSeconds: data.SuspendTimeout.ValueInt64(),
+			PoolerEnabled:         data.PoolerEnabled.ValueBool(),
+			PoolerMode:            data.PoolerMode.ValueString(),
+			Disabled:              data.Disabled.ValueBool(),
+			Provisioner:           data.ComputeProvisioner.ValueString(),
 		},
 	}
 
+	settings, diags := endpointSettingsInput(ctx, data.PgSettings, state.PgSettings)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input.Endpoint.Settings = settings
+
 	endpoint, err := endpointUpdate(r.client, data.ProjectId.ValueString(), data.Id.ValueString(), input)
 
 	if err != nil {
@@ -251,15 +547,32 @@ func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateReques
 
 	tflog.Trace(ctx, "updated a endpoint")
 
+	endpoint, err = endpointApplyDesiredState(r.client, endpoint, data.DesiredState)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change endpoint state, got error: %s", err))
+		return
+	}
+
 	data.Id = types.StringValue(endpoint.Endpoint.Id)
 	data.BranchId = types.StringValue(endpoint.Endpoint.BranchId)
 	data.ProjectId = types.StringValue(endpoint.Endpoint.ProjectId)
 	data.Type = types.StringValue(endpoint.Endpoint.Type)
 	data.Host = types.StringValue(endpoint.Endpoint.Host)
+	data.PoolerHost = types.StringValue(endpointPoolerHost(endpoint.Endpoint.Host))
+	data.ProxyHost = types.StringValue(endpoint.Endpoint.ProxyHost)
+	data.RegionId = types.StringValue(endpoint.Endpoint.RegionId)
+	data.CreatedAt = types.StringValue(endpoint.Endpoint.CreatedAt)
+	data.LastActive = types.StringPointerValue(endpoint.Endpoint.LastActive)
 	data.MinCu = types.Float64Value(endpoint.Endpoint.AutoscalingLimitMinCu)
 	data.MaxCu = types.Float64Value(endpoint.Endpoint.AutoscalingLimitMaxCu)
 	data.ComputeProvisioner = types.StringValue(endpoint.Endpoint.ComputeProvisioner)
 	data.SuspendTimeout = types.Int64Value(endpoint.Endpoint.SuspendTimeoutSeconds)
+	data.PgSettings = pgSettingsValue(data.PgSettings, endpoint.Endpoint.Settings.PgSettings)
+	data.PoolerEnabled = types.BoolValue(endpoint.Endpoint.PoolerEnabled)
+	data.PoolerMode = types.StringValue(endpoint.Endpoint.PoolerMode)
+	data.Disabled = types.BoolValue(endpoint.Endpoint.Disabled)
+	data.CurrentState = types.StringValue(endpoint.Endpoint.CurrentState)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -298,3 +611,104 @@ func (r *EndpointResource) ImportState(ctx context.Context, req resource.ImportS
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
 }
+
+// endpointApplyDesiredState starts or suspends the endpoint when its current state does not
+// match the desired state. Neon reports suspended endpoints as idle.
+func endpointApplyDesiredState(client *http.Client, endpoint EndpointOutput, desired types.String) (EndpointOutput, error) {
+	projectId := endpoint.Endpoint.ProjectId
+	endpointId := endpoint.Endpoint.Id
+
+	switch {
+	case desired.ValueString() == "active" && endpoint.Endpoint.CurrentState != "active":
+		return endpointStart(client, projectId, endpointId)
+	case desired.ValueString() == "suspended" && endpoint.Endpoint.CurrentState != "idle":
+		return endpointSuspend(client, projectId, endpointId)
+	}
+
+	return endpoint, nil
+}
+
+// endpointDesiredState reports the state the endpoint is in as its desired_state, so that an
+// endpoint which suspended or started on its own shows up as drift. It is left alone when not
+// set or while the endpoint is changing state.
+func endpointDesiredState(desired types.String, currentState string) types.String {
+	if desired.IsNull() || desired.IsUnknown() {
+		return desired
+	}
+
+	switch currentState {
+	case "active":
+		return types.StringValue("active")
+	case "idle":
+		return types.StringValue("suspended")
+	}
+
+	return desired
+}
+
+// desiredStateFrom returns the desired_state of an endpoint object.
+func desiredStateFrom(endpoint types.Object) types.String {
+	if value, ok := endpoint.Attributes()["desired_state"].(types.String); ok {
+		return value
+	}
+
+	return types.StringNull()
+}
+
+// endpointSettingsInput returns the settings to send for the planned pg_settings. Settings
+// are only sent when they are configured or were configured before, so that they can be cleared.
+func endpointSettingsInput(ctx context.Context, plan types.Map, state types.Map) (*EndpointSettings, diag.Diagnostics) {
+	if plan.IsNull() && state.IsNull() {
+		return nil, nil
+	}
+
+	settings := EndpointSettings{
+		PgSettings: map[string]string{},
+	}
+
+	if plan.IsNull() {
+		return &settings, nil
+	}
+
+	diags := plan.ElementsAs(ctx, &settings.PgSettings, false)
+
+	return &settings, diags
+}
+
+// pgSettingsValue returns the pg_settings known to the prior value, ignoring the ones added by Neon.
+func pgSettingsValue(prior types.Map, settings map[string]string) types.Map {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.MapNull(types.StringType)
+	}
+
+	values := map[string]attr.Value{}
+
+	for key := range prior.Elements() {
+		if value, ok := settings[key]; ok {
+			values[key] = types.StringValue(value)
+		}
+	}
+
+	return types.MapValueMust(types.StringType, values)
+}
+
+// pgSettingsFrom returns the pg_settings of an endpoint object.
+func pgSettingsFrom(endpoint types.Object) types.Map {
+	if value, ok := endpoint.Attributes()["pg_settings"].(types.Map); ok {
+		return value
+	}
+
+	return types.MapNull(types.StringType)
+}
+
+// endpointPoolerHost returns the host for pooled connections to the endpoint, which has
+// the endpoint identifier in its first label suffixed with -pooler.
+func endpointPoolerHost(host string) string {
+	label, domain, found := strings.Cut(host, ".")
+
+	if !found || len(label) == 0 {
+		return host
+	}
+
+	return label + "-pooler." + domain
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -22,10 +23,17 @@ func TestAccEndpointResourceDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_endpoint.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "type", "read_only"),
 					resource.TestMatchResourceAttr("neon_endpoint.test", "host", hostRegex("us-east-2")),
+					resource.TestMatchResourceAttr("neon_endpoint.test", "pooler_host", poolerHostRegex("us-east-2")),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "proxy_host", "us-east-2.aws.neon.tech"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "region_id", "aws-us-east-2"),
+					resource.TestMatchResourceAttr("neon_endpoint.test", "created_at", existRegex()),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "min_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "max_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "suspend_timeout", "0"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_enabled", "false"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_mode", "transaction"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "disabled", "false"),
 				),
 			},
 			// ImportState testing
@@ -48,6 +56,8 @@ func TestAccEndpointResourceDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_endpoint.test", "max_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "suspend_timeout", "0"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_enabled", "false"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_mode", "transaction"),
 				),
 			},
 			// Update and Read testing
@@ -63,6 +73,8 @@ func TestAccEndpointResourceDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_endpoint.test", "max_cu", "2"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "suspend_timeout", "3600"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_enabled", "true"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_mode", "session"),
 				),
 			},
 			// ImportState testing
@@ -95,6 +107,8 @@ func TestAccEndpointResourceNonDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_endpoint.test", "max_cu", "2"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "suspend_timeout", "3600"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_enabled", "true"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_mode", "session"),
 				),
 			},
 			// ImportState testing
@@ -117,6 +131,8 @@ func TestAccEndpointResourceNonDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_endpoint.test", "max_cu", "2"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "suspend_timeout", "3600"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_enabled", "true"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_mode", "session"),
 				),
 			},
 			// Update will null values
@@ -132,6 +148,8 @@ func TestAccEndpointResourceNonDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_endpoint.test", "max_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "compute_provisioner", "k8s-neonvm"),
 					resource.TestCheckResourceAttr("neon_endpoint.test", "suspend_timeout", "0"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_enabled", "false"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pooler_mode", "transaction"),
 				),
 			},
 			// ImportState testing
@@ -146,6 +164,195 @@ func TestAccEndpointResourceNonDefault(t *testing.T) {
 	})
 }
 
+func TestAccEndpointResourceWaitForReady(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEndpointResourceConfigWaitForReady(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "wait_for_ready", "true"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "timeouts.create", "10m"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEndpointResourcePgSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEndpointResourceConfigPgSettings("64MB"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pg_settings.%", "1"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pg_settings.work_mem", "64MB"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccEndpointResourceConfigPgSettings("128MB"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pg_settings.%", "1"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "pg_settings.work_mem", "128MB"),
+				),
+			},
+			// Update with null values
+			{
+				Config: testAccEndpointResourceConfigDefault(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckNoResourceAttr("neon_endpoint.test", "pg_settings"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEndpointResourceDesiredState(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEndpointResourceConfigDesiredState("suspended", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "desired_state", "suspended"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "disabled", "false"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccEndpointResourceConfigDesiredState("active", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "desired_state", "active"),
+				),
+			},
+			// Update with disabled
+			{
+				Config: testAccEndpointResourceConfigDesiredState("suspended", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "desired_state", "suspended"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "disabled", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEndpointResourceInvalidComputeSize(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Min larger than max
+			{
+				Config:      testAccEndpointResourceConfigComputeSize(4, 1),
+				ExpectError: regexp.MustCompile("must be less than or equal to max_cu"),
+			},
+			// Spread larger than allowed
+			{
+				Config:      testAccEndpointResourceConfigComputeSize(0.25, 16),
+				ExpectError: regexp.MustCompile("can be at most 8 compute units more than min_cu"),
+			},
+			// Autoscaling above the largest autoscaling size
+			{
+				Config:      testAccEndpointResourceConfigComputeSize(18, 20),
+				ExpectError: regexp.MustCompile("Autoscaling is only available up to 16 compute units"),
+			},
+		},
+	})
+}
+
+func TestAccEndpointResourceReadWrite(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEndpointResourceConfigReadWrite(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "type", "read_write"),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "adopt_existing", "false"),
+					resource.TestCheckNoResourceAttr("neon_branch.test", "endpoint"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "neon_endpoint.test",
+				ImportState:             true,
+				ImportStateIdFunc:       endpointImportIdFunc,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"current_state"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEndpointResourceReadWriteConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with the endpoint already managed by the branch
+			{
+				Config:      testAccEndpointResourceConfigReadWrite(true),
+				ExpectError: regexp.MustCompile("Conflicting Endpoint"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEndpointResourceComputeProvisioner(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid provisioner
+			{
+				Config:      testAccEndpointResourceConfigComputeProvisioner("k8s-vm"),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccEndpointResourceConfigComputeProvisioner("k8s-pod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "compute_provisioner", "k8s-pod"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccEndpointResourceConfigComputeProvisioner("k8s-neonvm"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_endpoint.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_endpoint.test", "compute_provisioner", "k8s-neonvm"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
 func testAccEndpointResourceConfigDefault() string {
 	return `
 resource "neon_endpoint" "test" {
@@ -163,10 +370,94 @@ resource "neon_endpoint" "test" {
   min_cu = 1
   max_cu = 2
   suspend_timeout = 3600
+  pooler_enabled = true
+  pooler_mode = "session"
 }
 `
 }
 
+func testAccEndpointResourceConfigWaitForReady() string {
+	return `
+resource "neon_endpoint" "test" {
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+  wait_for_ready = true
+
+  timeouts = {
+    create = "10m"
+  }
+}
+`
+}
+
+func testAccEndpointResourceConfigPgSettings(workMem string) string {
+	return fmt.Sprintf(`
+resource "neon_endpoint" "test" {
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+
+  pg_settings = {
+    work_mem = "%s"
+  }
+}
+`, workMem)
+}
+
+func testAccEndpointResourceConfigDesiredState(desiredState string, disabled bool) string {
+	return fmt.Sprintf(`
+resource "neon_endpoint" "test" {
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+  desired_state = "%s"
+  disabled = %t
+}
+`, desiredState, disabled)
+}
+
+func testAccEndpointResourceConfigComputeSize(minCu float64, maxCu float64) string {
+	return fmt.Sprintf(`
+resource "neon_endpoint" "test" {
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+  min_cu = %g
+  max_cu = %g
+}
+`, minCu, maxCu)
+}
+
+func testAccEndpointResourceConfigReadWrite(branchEndpoint bool) string {
+	endpoint := ""
+
+	if branchEndpoint {
+		endpoint = "endpoint = {}"
+	}
+
+	return fmt.Sprintf(`
+resource "neon_branch" "test" {
+  name       = "analytics"
+  project_id = "polished-snowflake-328957"
+
+  %s
+}
+
+resource "neon_endpoint" "test" {
+  branch_id = neon_branch.test.id
+  project_id = "polished-snowflake-328957"
+  type = "read_write"
+}
+`, endpoint)
+}
+
+func testAccEndpointResourceConfigComputeProvisioner(provisioner string) string {
+	return fmt.Sprintf(`
+resource "neon_endpoint" "test" {
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+  compute_provisioner = "%s"
+}
+`, provisioner)
+}
+
 func endpointImportIdFunc(state *terraform.State) (string, error) {
 	rawState, ok := state.RootModule().Resources["neon_endpoint.test"]
 
@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &ExtensionResource{}
+var _ resource.ResourceWithImportState = &ExtensionResource{}
+
+func NewExtensionResource() resource.Resource {
+	return &ExtensionResource{}
+}
+
+type ExtensionResource struct {
+	client *http.Client
+}
+
+type ExtensionResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Version      types.String `tfsdk:"version"`
+	Schema       types.String `tfsdk:"schema"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	RoleName     types.String `tfsdk:"role_name"`
+	BranchId     types.String `tfsdk:"branch_id"`
+	ProjectId    types.String `tfsdk:"project_id"`
+}
+
+func (r *ExtensionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_extension"
+}
+
+func (r *ExtensionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Postgres extension in a Neon database, managed over SQL. The project must store passwords.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the extension.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the extension.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the extension. Changing it updates the extension. Defaults to the default version of the extension.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "Schema of the extension objects. Defaults to the first schema in the search path.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Database the extension belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Role to connect as. Defaults to the owner of the database.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "Branch the database belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project the database belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+		},
+	}
+}
+
+func (r *ExtensionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ExtensionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ExtensionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = extensionCreate(ctx, conn, Extension{
+		Name:    data.Name.ValueString(),
+		Version: data.Version.ValueString(),
+		Schema:  data.Schema.ValueString(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create extension, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created an extension")
+
+	extension, err := extensionRead(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extension, got error: %s", err))
+		return
+	}
+
+	if extension == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extension, %s is not installed", data.Name.ValueString()))
+		return
+	}
+
+	data.Id = types.StringValue(extensionId(data))
+	data.Version = types.StringValue(extension.Version)
+	data.Schema = types.StringValue(extension.Schema)
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExtensionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ExtensionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	extension, err := extensionRead(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extension, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "read an extension")
+
+	if extension == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(extensionId(data))
+	data.Version = types.StringValue(extension.Version)
+	data.Schema = types.StringValue(extension.Schema)
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExtensionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *ExtensionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	current, err := extensionRead(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extension, got error: %s", err))
+		return
+	}
+
+	if current == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extension, %s is not installed", data.Name.ValueString()))
+		return
+	}
+
+	err = extensionUpdate(ctx, conn, *current, Extension{
+		Name:    data.Name.ValueString(),
+		Version: data.Version.ValueString(),
+		Schema:  data.Schema.ValueString(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update extension, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated an extension")
+
+	extension, err := extensionRead(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extension, got error: %s", err))
+		return
+	}
+
+	if extension == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extension, %s is not installed", data.Name.ValueString()))
+		return
+	}
+
+	data.Id = types.StringValue(extensionId(data))
+	data.Version = types.StringValue(extension.Version)
+	data.Schema = types.StringValue(extension.Schema)
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExtensionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ExtensionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = extensionDelete(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete extension, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted an extension")
+}
+
+func (r *ExtensionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id:branch_id:database_name:name. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[3])...)
+}
+
+// extensionId extends the project_id:branch_id:name identifier of the database
+// with the name of the extension.
+func extensionId(data *ExtensionResourceModel) string {
+	return strings.Join(
+		[]string{
+			data.ProjectId.ValueString(),
+			data.BranchId.ValueString(),
+			data.DatabaseName.ValueString(),
+			data.Name.ValueString(),
+		},
+		":",
+	)
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccExtensionResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccExtensionResourceConfigDefault("1.5"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_extension.test", "id", "polished-snowflake-328957:br-patient-mode-718259:budget-app:pg_trgm"),
+					resource.TestCheckResourceAttr("neon_extension.test", "name", "pg_trgm"),
+					resource.TestCheckResourceAttr("neon_extension.test", "version", "1.5"),
+					resource.TestCheckResourceAttr("neon_extension.test", "schema", "public"),
+					resource.TestCheckResourceAttr("neon_extension.test", "database_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_extension.test", "role_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_extension.test", "branch_id", "br-patient-mode-718259"),
+					resource.TestCheckResourceAttr("neon_extension.test", "project_id", "polished-snowflake-328957"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_extension.test",
+				ImportState:       true,
+				ImportStateId:     "polished-snowflake-328957:br-patient-mode-718259:budget-app:pg_trgm",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccExtensionResourceConfigDefault("1.6"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_extension.test", "id", "polished-snowflake-328957:br-patient-mode-718259:budget-app:pg_trgm"),
+					resource.TestCheckResourceAttr("neon_extension.test", "name", "pg_trgm"),
+					resource.TestCheckResourceAttr("neon_extension.test", "version", "1.6"),
+					resource.TestCheckResourceAttr("neon_extension.test", "schema", "public"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccExtensionResourceConfigDefault(version string) string {
+	return fmt.Sprintf(`
+resource "neon_extension" "test" {
+  name = "pg_trgm"
+  version = "%s"
+  database_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+`, version)
+}
@@ -0,0 +1,445 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &GrantResource{}
+var _ resource.ResourceWithValidateConfig = &GrantResource{}
+
+func NewGrantResource() resource.Resource {
+	return &GrantResource{}
+}
+
+type GrantResource struct {
+	client *http.Client
+}
+
+type GrantResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Grantee         types.String `tfsdk:"grantee"`
+	ObjectType      types.String `tfsdk:"object_type"`
+	Schema          types.String `tfsdk:"schema"`
+	Objects         types.Set    `tfsdk:"objects"`
+	Privileges      types.Set    `tfsdk:"privileges"`
+	WithGrantOption types.Bool   `tfsdk:"with_grant_option"`
+	DatabaseName    types.String `tfsdk:"database_name"`
+	RoleName        types.String `tfsdk:"role_name"`
+	BranchId        types.String `tfsdk:"branch_id"`
+	ProjectId       types.String `tfsdk:"project_id"`
+}
+
+func (r *GrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grant"
+}
+
+func (r *GrantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Privileges of a role on a database, schema, tables or sequences, managed over SQL. The provider connects to the branch with the credentials of `role_name`, so the project must store passwords.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the grant.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"grantee": schema.StringAttribute{
+				MarkdownDescription: "Name of the role receiving the privileges.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"object_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the objects. **Options:** `database`, `schema`, `table`, `sequence`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("database", "schema", "table", "sequence"),
+				},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "Schema of the objects. Required unless `object_type` is `database`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"objects": schema.SetAttribute{
+				MarkdownDescription: "Names of the tables or sequences. Grants on all of them in `schema` when not set.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.UTF8LengthAtLeast(1)),
+				},
+			},
+			"privileges": schema.SetAttribute{
+				MarkdownDescription: "Privileges to grant, for example `SELECT` or `USAGE`.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf("CREATE", "CONNECT", "TEMPORARY", "USAGE", "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"),
+					),
+				},
+			},
+			"with_grant_option": schema.BoolAttribute{
+				MarkdownDescription: "Whether the grantee can grant the privileges to others. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Database the objects belong to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Role to connect as, usually the owner of the objects.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "Branch the database belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project the database belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+		},
+	}
+}
+
+func (r *GrantResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *GrantResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ObjectType.IsUnknown() || data.ObjectType.IsNull() {
+		return
+	}
+
+	objectType := data.ObjectType.ValueString()
+
+	if objectType == "database" && !data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("schema"), "Invalid Grant", "`schema` can not be set when `object_type` is `database`.")
+	}
+
+	if objectType != "database" && data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("schema"), "Invalid Grant", fmt.Sprintf("`schema` is required when `object_type` is `%s`.", objectType))
+	}
+
+	if (objectType == "database" || objectType == "schema") && !data.Objects.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("objects"), "Invalid Grant", fmt.Sprintf("`objects` can not be set when `object_type` is `%s`.", objectType))
+	}
+
+	if data.Privileges.IsUnknown() || data.Privileges.IsNull() {
+		return
+	}
+
+	var privileges []types.String
+
+	resp.Diagnostics.Append(data.Privileges.ElementsAs(ctx, &privileges, true)...)
+
+	for _, privilege := range privileges {
+		if privilege.IsUnknown() || privilege.IsNull() {
+			continue
+		}
+
+		if !slices.Contains(grantPrivileges[objectType], privilege.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("privileges"),
+				"Invalid Grant",
+				fmt.Sprintf("`%s` can not be granted on a %s, valid privileges are %s.", privilege.ValueString(), objectType, strings.Join(grantPrivileges[objectType], ", ")),
+			)
+		}
+	}
+}
+
+func (r *GrantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *GrantResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant, diags := grantFrom(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = grantCreate(ctx, conn, grant)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create grant, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a grant")
+
+	data.Id = types.StringValue(grantId(data))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *GrantResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := grantFrom(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	grant, err := grantRead(ctx, conn, current)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read grant, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "read a grant")
+
+	if len(grant.Privileges) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	privileges, diags := types.SetValueFrom(ctx, types.StringType, grant.Privileges)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Privileges = privileges
+	data.WithGrantOption = types.BoolValue(grant.WithGrantOption)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *GrantResourceModel
+	var state *GrantResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := grantFrom(ctx, state)
+
+	resp.Diagnostics.Append(diags...)
+
+	grant, diags := grantFrom(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = grantUpdate(ctx, conn, current, grant)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update grant, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated a grant")
+
+	data.Id = types.StringValue(grantId(data))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *GrantResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grant, diags := grantFrom(ctx, data)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = grantDelete(ctx, conn, grant)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete grant, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a grant")
+}
+
+func grantFrom(ctx context.Context, data *GrantResourceModel) (Grant, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	grant := Grant{
+		Database:        data.DatabaseName.ValueString(),
+		Role:            data.Grantee.ValueString(),
+		ObjectType:      data.ObjectType.ValueString(),
+		Schema:          data.Schema.ValueString(),
+		WithGrantOption: data.WithGrantOption.ValueBool(),
+	}
+
+	diags.Append(data.Objects.ElementsAs(ctx, &grant.Objects, false)...)
+	diags.Append(data.Privileges.ElementsAs(ctx, &grant.Privileges, false)...)
+
+	return grant, diags
+}
+
+func grantId(data *GrantResourceModel) string {
+	return strings.Join(
+		[]string{
+			data.ProjectId.ValueString(),
+			data.BranchId.ValueString(),
+			data.DatabaseName.ValueString(),
+			data.Grantee.ValueString(),
+			data.ObjectType.ValueString(),
+			data.Schema.ValueString(),
+		},
+		":",
+	)
+}
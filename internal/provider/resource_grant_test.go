@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGrantResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGrantResourceConfigDefault(`["SELECT"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_grant.test", "id", "polished-snowflake-328957:br-patient-mode-718259:budget-app:reporting:schema:public"),
+					resource.TestCheckResourceAttr("neon_grant.test", "grantee", "reporting"),
+					resource.TestCheckResourceAttr("neon_grant.test", "object_type", "schema"),
+					resource.TestCheckResourceAttr("neon_grant.test", "schema", "public"),
+					resource.TestCheckNoResourceAttr("neon_grant.test", "objects"),
+					resource.TestCheckResourceAttr("neon_grant.test", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("neon_grant.test", "privileges.*", "USAGE"),
+					resource.TestCheckResourceAttr("neon_grant.test", "with_grant_option", "false"),
+					resource.TestCheckResourceAttr("neon_grant.test", "database_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_grant.test", "role_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_grant.test", "branch_id", "br-patient-mode-718259"),
+					resource.TestCheckResourceAttr("neon_grant.test", "project_id", "polished-snowflake-328957"),
+					resource.TestCheckResourceAttr("neon_grant.tables", "id", "polished-snowflake-328957:br-patient-mode-718259:budget-app:reporting:table:public"),
+					resource.TestCheckResourceAttr("neon_grant.tables", "object_type", "table"),
+					resource.TestCheckResourceAttr("neon_grant.tables", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("neon_grant.tables", "privileges.*", "SELECT"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccGrantResourceConfigDefault(`["SELECT", "INSERT"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_grant.tables", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("neon_grant.tables", "privileges.*", "SELECT"),
+					resource.TestCheckTypeSetElemAttr("neon_grant.tables", "privileges.*", "INSERT"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccGrantResourceInvalidPrivilege(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "neon_grant" "test" {
+  grantee = "reporting"
+  object_type = "schema"
+  schema = "public"
+  privileges = ["SELECT"]
+  database_name = "budget-app"
+  role_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+`,
+				ExpectError: regexp.MustCompile("`SELECT` can not be granted on a schema"),
+			},
+		},
+	})
+}
+
+func testAccGrantResourceConfigDefault(privileges string) string {
+	return fmt.Sprintf(`
+resource "neon_role" "test" {
+  name = "reporting"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_grant" "test" {
+  grantee = neon_role.test.name
+  object_type = "schema"
+  schema = "public"
+  privileges = ["USAGE"]
+  database_name = "budget-app"
+  role_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_grant" "tables" {
+  grantee = neon_role.test.name
+  object_type = "table"
+  schema = "public"
+  privileges = %s
+  database_name = "budget-app"
+  role_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+`, privileges)
+}
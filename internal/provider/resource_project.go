@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,11 +27,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"golang.org/x/exp/slices"
 )
 
 var _ resource.Resource = &ProjectResource{}
 var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithValidateConfig = &ProjectResource{}
 
 func logicalReplication() planmodifier.Bool {
 	return logicalReplicationModifier{}
@@ -56,6 +59,197 @@ func (m logicalReplicationModifier) PlanModifyBool(_ context.Context, req planmo
 	}
 }
 
+func storePasswordsUnchanged() planmodifier.Bool {
+	return storePasswordsUnchangedModifier{}
+}
+
+type storePasswordsUnchangedModifier struct{}
+
+func (m storePasswordsUnchangedModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m storePasswordsUnchangedModifier) MarkdownDescription(_ context.Context) string {
+	return "Cannot be changed after the project is created."
+}
+
+func (m storePasswordsUnchangedModifier) PlanModifyBool(_ context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	// Nothing to do on resource creation or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.IsUnknown() || req.StateValue.IsNull() || req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	// Replacing the project would lose all of its data, so the change is rejected instead.
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Store Passwords Cannot Change",
+		"Neon cannot change whether a project stores passwords after it is created. Create a new project with the wanted setting and move the data to it instead.",
+	)
+}
+
+func keepDefaultOnlyDropped() planmodifier.Bool {
+	return keepDefaultOnlyDroppedModifier{}
+}
+
+type keepDefaultOnlyDroppedModifier struct{}
+
+func (m keepDefaultOnlyDroppedModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m keepDefaultOnlyDroppedModifier) MarkdownDescription(_ context.Context) string {
+	return "Can only be switched from `true` to `false` after the project is created."
+}
+
+func (m keepDefaultOnlyDroppedModifier) PlanModifyBool(_ context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	// Nothing to do on resource creation or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.IsUnknown() || req.StateValue.ValueBool() || !req.PlanValue.ValueBool() {
+		return
+	}
+
+	// The default item was deleted when the project was created, so it can not be kept anymore.
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Default Cannot Be Kept",
+		fmt.Sprintf("%s can only be set to true when the project is created, the default item has already been deleted.", req.Path),
+	)
+}
+
+func keptDefault(keep string) planmodifier.String {
+	return keptDefaultModifier{keep: keep}
+}
+
+type keptDefaultModifier struct {
+	keep string
+}
+
+func (m keptDefaultModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m keptDefaultModifier) MarkdownDescription(_ context.Context) string {
+	return fmt.Sprintf("Stays the same while `%s` is true and becomes null when it is set to false.", m.keep)
+}
+
+func (m keptDefaultModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to do on resource creation or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var keep types.Bool
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(m.keep), &keep)...)
+
+	if keep.IsUnknown() {
+		return
+	}
+
+	if keep.ValueBool() {
+		resp.PlanValue = req.StateValue
+	} else {
+		resp.PlanValue = types.StringNull()
+	}
+}
+
+func rolePasswordsFollowRoles() planmodifier.Map {
+	return rolePasswordsFollowRolesModifier{}
+}
+
+type rolePasswordsFollowRolesModifier struct{}
+
+func (m rolePasswordsFollowRolesModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m rolePasswordsFollowRolesModifier) MarkdownDescription(_ context.Context) string {
+	return "Only changes when `roles` changes."
+}
+
+func (m rolePasswordsFollowRolesModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	// Nothing to do on resource destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var roles types.Set
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("roles"), &roles)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if roles.IsNull() {
+		resp.PlanValue = types.MapNull(types.StringType)
+		return
+	}
+
+	// Nothing else to do on resource creation.
+	if req.State.Raw.IsNull() || req.StateValue.IsNull() {
+		return
+	}
+
+	var currentRoles types.Set
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("roles"), &currentRoles)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if roles.Equal(currentRoles) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func projectBranchFollow() planmodifier.Object {
+	return projectBranchFollowModifier{}
+}
+
+type projectBranchFollowModifier struct{}
+
+func (m projectBranchFollowModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m projectBranchFollowModifier) MarkdownDescription(_ context.Context) string {
+	return "Follows the current default branch when another branch is set as default."
+}
+
+func (m projectBranchFollowModifier) PlanModifyObject(ctx context.Context, req planmodifier.ObjectRequest, resp *planmodifier.ObjectResponse) {
+	// Nothing to do on resource creation or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	ownBranchId, diags := projectOwnBranchId(ctx, req.Private)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The settings belong to the branch the project was created with. Once another branch is the
+	// default, that branch is managed by its neon_branch and the configured settings are ignored.
+	if projectFollowsDefault(ownBranchId, req.StateValue) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
 func NewProjectResource() resource.Resource {
 	return &ProjectResource{}
 }
@@ -67,10 +261,18 @@ type ProjectResource struct {
 type ProjectResourceBranchEndpointModel struct {
 	Id                 types.String  `tfsdk:"id"`
 	Host               types.String  `tfsdk:"host"`
+	PoolerHost         types.String  `tfsdk:"pooler_host"`
+	ProxyHost          types.String  `tfsdk:"proxy_host"`
+	RegionId           types.String  `tfsdk:"region_id"`
+	CreatedAt          types.String  `tfsdk:"created_at"`
+	LastActive         types.String  `tfsdk:"last_active"`
 	MinCu              types.Float64 `tfsdk:"min_cu"`
 	MaxCu              types.Float64 `tfsdk:"max_cu"`
 	ComputeProvisioner types.String  `tfsdk:"compute_provisioner"`
 	SuspendTimeout     types.Int64   `tfsdk:"suspend_timeout"`
+	PgSettings         types.Map     `tfsdk:"pg_settings"`
+	PoolerEnabled      types.Bool    `tfsdk:"pooler_enabled"`
+	PoolerMode         types.String  `tfsdk:"pooler_mode"`
 }
 
 var allowedIpsAttrTypes = map[string]attr.Type{
@@ -86,39 +288,93 @@ type ProjectResourceAllowedIpsModel struct {
 var branchEndpointAttrTypes = map[string]attr.Type{
 	"id":                  types.StringType,
 	"host":                types.StringType,
+	"pooler_host":         types.StringType,
+	"proxy_host":          types.StringType,
+	"region_id":           types.StringType,
+	"created_at":          types.StringType,
+	"last_active":         types.StringType,
 	"min_cu":              types.Float64Type,
 	"max_cu":              types.Float64Type,
 	"compute_provisioner": types.StringType,
 	"suspend_timeout":     types.Int64Type,
+	"pg_settings":         types.MapType{ElemType: types.StringType},
+	"pooler_enabled":      types.BoolType,
+	"pooler_mode":         types.StringType,
 }
 
 type ProjectResourceBranchModel struct {
-	Id        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Protected types.Bool   `tfsdk:"protected"`
-	Endpoint  types.Object `tfsdk:"endpoint"`
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Protected    types.Bool   `tfsdk:"protected"`
+	CurrentState types.String `tfsdk:"current_state"`
+	LogicalSize  types.Int64  `tfsdk:"logical_size"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	UpdatedAt    types.String `tfsdk:"updated_at"`
+	LastResetAt  types.String `tfsdk:"last_reset_at"`
+	Endpoint     types.Object `tfsdk:"endpoint"`
 }
 
 var branchAttrTypes = map[string]attr.Type{
-	"id":        types.StringType,
-	"name":      types.StringType,
-	"protected": types.BoolType,
+	"id":            types.StringType,
+	"name":          types.StringType,
+	"protected":     types.BoolType,
+	"current_state": types.StringType,
+	"logical_size":  types.Int64Type,
+	"created_at":    types.StringType,
+	"updated_at":    types.StringType,
+	"last_reset_at": types.StringType,
 	"endpoint": types.ObjectType{
 		AttrTypes: branchEndpointAttrTypes,
 	},
 }
 
+type ProjectResourceRoleModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+var projectRoleAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+}
+
+type ProjectResourceDatabaseModel struct {
+	Name      types.String `tfsdk:"name"`
+	OwnerName types.String `tfsdk:"owner_name"`
+}
+
+var projectDatabaseAttrTypes = map[string]attr.Type{
+	"name":       types.StringType,
+	"owner_name": types.StringType,
+}
+
+type ProjectResourceExtraBranchModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+var projectExtraBranchAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+}
+
 type ProjectResourceModel struct {
-	Id                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	PlatformId         types.String `tfsdk:"platform_id"`
-	RegionId           types.String `tfsdk:"region_id"`
-	OrgId              types.String `tfsdk:"org_id"`
-	PgVersion          types.Int64  `tfsdk:"pg_version"`
-	HistoryRetention   types.Int64  `tfsdk:"history_retention"`
-	Branch             types.Object `tfsdk:"branch"`
-	AllowedIps         types.Object `tfsdk:"allowed_ips"`
-	LogicalReplication types.Bool   `tfsdk:"logical_replication"`
+	Id                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	PlatformId          types.String `tfsdk:"platform_id"`
+	RegionId            types.String `tfsdk:"region_id"`
+	OrgId               types.String `tfsdk:"org_id"`
+	PgVersion           types.Int64  `tfsdk:"pg_version"`
+	HistoryRetention    types.Int64  `tfsdk:"history_retention"`
+	StorePasswords      types.Bool   `tfsdk:"store_passwords"`
+	KeepDefaultDatabase types.Bool   `tfsdk:"keep_default_database"`
+	KeepDefaultRole     types.Bool   `tfsdk:"keep_default_role"`
+	DefaultDatabaseName types.String `tfsdk:"default_database_name"`
+	DefaultRoleName     types.String `tfsdk:"default_role_name"`
+	DefaultRolePassword types.String `tfsdk:"default_role_password"`
+	Branch              types.Object `tfsdk:"branch"`
+	AllowedIps          types.Object `tfsdk:"allowed_ips"`
+	LogicalReplication  types.Bool   `tfsdk:"logical_replication"`
+	Roles               types.Set    `tfsdk:"roles"`
+	RolePasswords       types.Map    `tfsdk:"role_passwords"`
+	Databases           types.Set    `tfsdk:"databases"`
+	Branches            types.Set    `tfsdk:"branches"`
 }
 
 func (r *ProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -180,6 +436,55 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 					int64validator.OneOf(14, 15, 16, 17, 18),
 				},
 			},
+			"store_passwords": schema.BoolAttribute{
+				MarkdownDescription: "Whether Neon stores the passwords of the roles of the project. Without stored passwords, `neon_role` keeps the password it got when the role was created in state. Cannot be changed after the project is created. **Default** `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					storePasswordsUnchanged(),
+				},
+			},
+			"keep_default_database": schema.BoolAttribute{
+				MarkdownDescription: "Whether to keep the database Neon creates with the project instead of deleting it. Needs `keep_default_role` as the database is owned by the default role. Setting it to `false` later deletes the database, it can only be set to `true` when the project is created. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					keepDefaultOnlyDropped(),
+				},
+			},
+			"keep_default_role": schema.BoolAttribute{
+				MarkdownDescription: "Whether to keep the role Neon creates with the project instead of deleting it. Setting it to `false` later deletes the role, it can only be set to `true` when the project is created. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					keepDefaultOnlyDropped(),
+				},
+			},
+			"default_database_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the default database when it is kept.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					keptDefault("keep_default_database"),
+				},
+			},
+			"default_role_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the default role when it is kept.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					keptDefault("keep_default_role"),
+				},
+			},
+			"default_role_password": schema.StringAttribute{
+				MarkdownDescription: "Password of the default role when it is kept.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					keptDefault("keep_default_role"),
+				},
+			},
 			"history_retention": schema.Int64Attribute{
 				MarkdownDescription: "PITR history retention period of the project in seconds. **Default** `86400` (1 day).",
 				Optional:            true,
@@ -226,26 +531,103 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 					logicalReplication(),
 				},
 			},
+			"roles": schema.SetNestedAttribute{
+				MarkdownDescription: "Roles of the branch the project was created with, managed by the project. Only the roles listed here are tracked. Removing the attribute stops managing the roles without deleting them. Import leaves it unset, and existing roles listed afterwards are adopted instead of created.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the role.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.UTF8LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"role_passwords": schema.MapAttribute{
+				MarkdownDescription: "Passwords of the roles in `roles` by name. When the project does not store passwords, only the passwords of roles created by the project are known.",
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					rolePasswordsFollowRoles(),
+				},
+			},
+			"databases": schema.SetNestedAttribute{
+				MarkdownDescription: "Databases of the branch the project was created with, managed by the project. Only the databases listed here are tracked. Removing the attribute stops managing the databases without deleting them. Import leaves it unset, and existing databases listed afterwards are adopted instead of created.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the database.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.UTF8LengthAtLeast(1),
+							},
+						},
+						"owner_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the role owning the database.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.UTF8LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"branches": schema.SetNestedAttribute{
+				MarkdownDescription: "Additional branches of the project, created from the branch the project was created with and without a compute endpoint. Only the branches listed here are tracked. Removing the attribute stops managing the branches without deleting them. Import leaves it unset, and existing branches listed afterwards are adopted instead of created.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the branch.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.UTF8LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
 			"branch": schema.SingleNestedAttribute{
-				MarkdownDescription: "Default branch settings of the project.",
+				MarkdownDescription: "Default branch settings of the project. Follows the branch marked as default, so it changes when `set_as_default` is used on a `neon_branch`. The settings given here only apply to the branch the project was created with and are ignored while another branch is the default.",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					projectBranchFollow(),
+				},
 				Default: objectdefault.StaticValue(
 					types.ObjectValueMust(
 						branchAttrTypes,
 						map[string]attr.Value{
-							"id":        types.StringUnknown(),
-							"name":      types.StringValue("main"),
-							"protected": types.BoolValue(false),
+							"id":            types.StringUnknown(),
+							"name":          types.StringValue("main"),
+							"protected":     types.BoolValue(false),
+							"current_state": types.StringUnknown(),
+							"logical_size":  types.Int64Unknown(),
+							"created_at":    types.StringUnknown(),
+							"updated_at":    types.StringUnknown(),
+							"last_reset_at": types.StringUnknown(),
 							"endpoint": types.ObjectValueMust(
 								branchEndpointAttrTypes,
 								map[string]attr.Value{
 									"id":                  types.StringUnknown(),
 									"host":                types.StringUnknown(),
+									"pooler_host":         types.StringUnknown(),
+									"proxy_host":          types.StringUnknown(),
+									"region_id":           types.StringUnknown(),
+									"created_at":          types.StringUnknown(),
+									"last_active":         types.StringUnknown(),
 									"min_cu":              types.Float64Value(0.25),
 									"max_cu":              types.Float64Value(0.25),
-									"compute_provisioner": types.StringValue("k8s-neonvm"),
+									"compute_provisioner": types.StringUnknown(),
 									"suspend_timeout":     types.Int64Value(0),
+									"pg_settings":         types.MapNull(types.StringType),
+									"pooler_enabled":      types.BoolValue(false),
+									"pooler_mode":         types.StringValue("transaction"),
 								},
 							),
 						},
@@ -274,6 +656,41 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 						Computed:            true,
 						Default:             booldefault.StaticBool(false),
 					},
+					"current_state": schema.StringAttribute{
+						MarkdownDescription: "Current state of the branch.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"logical_size": schema.Int64Attribute{
+						MarkdownDescription: "Logical size of the branch in bytes.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
+						},
+					},
+					"created_at": schema.StringAttribute{
+						MarkdownDescription: "Timestamp when the branch was created.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"updated_at": schema.StringAttribute{
+						MarkdownDescription: "Timestamp when the branch was last updated.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"last_reset_at": schema.StringAttribute{
+						MarkdownDescription: "Timestamp when the branch was last reset from its parent.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
 					"endpoint": schema.SingleNestedAttribute{
 						MarkdownDescription: "Read-write compute endpoint settings of the branch.",
 						Optional:            true,
@@ -284,10 +701,18 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 								map[string]attr.Value{
 									"id":                  types.StringUnknown(),
 									"host":                types.StringUnknown(),
+									"pooler_host":         types.StringUnknown(),
+									"proxy_host":          types.StringUnknown(),
+									"region_id":           types.StringUnknown(),
+									"created_at":          types.StringUnknown(),
+									"last_active":         types.StringUnknown(),
 									"min_cu":              types.Float64Value(0.25),
 									"max_cu":              types.Float64Value(0.25),
-									"compute_provisioner": types.StringValue("k8s-neonvm"),
+									"compute_provisioner": types.StringUnknown(),
 									"suspend_timeout":     types.Int64Value(0),
+									"pg_settings":         types.MapNull(types.StringType),
+									"pooler_enabled":      types.BoolValue(false),
+									"pooler_mode":         types.StringValue("transaction"),
 								},
 							),
 						),
@@ -306,6 +731,41 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 									stringplanmodifier.UseStateForUnknown(),
 								},
 							},
+							"pooler_host": schema.StringAttribute{
+								MarkdownDescription: "Pooler host of the endpoint, used for pooled connections.",
+								Computed:            true,
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.UseStateForUnknown(),
+								},
+							},
+							"proxy_host": schema.StringAttribute{
+								MarkdownDescription: "Proxy host of the region of the endpoint.",
+								Computed:            true,
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.UseStateForUnknown(),
+								},
+							},
+							"region_id": schema.StringAttribute{
+								MarkdownDescription: "Region of the endpoint.",
+								Computed:            true,
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.UseStateForUnknown(),
+								},
+							},
+							"created_at": schema.StringAttribute{
+								MarkdownDescription: "Timestamp when the endpoint was created.",
+								Computed:            true,
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.UseStateForUnknown(),
+								},
+							},
+							"last_active": schema.StringAttribute{
+								MarkdownDescription: "Timestamp when the endpoint was last active.",
+								Computed:            true,
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.UseStateForUnknown(),
+								},
+							},
 							"min_cu": schema.Float64Attribute{
 								MarkdownDescription: "Minimum number of compute units for the endpoint. **Default** `0.25`.",
 								Optional:            true,
@@ -325,11 +785,15 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 								},
 							},
 							"compute_provisioner": schema.StringAttribute{
-								MarkdownDescription: "Provisioner of the endpoint.",
+								MarkdownDescription: "Provisioner of the endpoint. Either `k8s-pod` or `k8s-neonvm`. Defaults to the provisioner chosen by Neon.",
+								Optional:            true,
 								Computed:            true,
 								PlanModifiers: []planmodifier.String{
 									stringplanmodifier.UseStateForUnknown(),
 								},
+								Validators: []validator.String{
+									ComputeProvisionerValidator,
+								},
 							},
 							"suspend_timeout": schema.Int64Attribute{
 								MarkdownDescription: "Suspend timeout of the endpoint. **Default** `0`.",
@@ -340,6 +804,26 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 									int64validator.Between(-1, 604800),
 								},
 							},
+							"pooler_enabled": schema.BoolAttribute{
+								MarkdownDescription: "Whether connection pooling is enabled for the endpoint. **Default** `false`.",
+								Optional:            true,
+								Computed:            true,
+								Default:             booldefault.StaticBool(false),
+							},
+							"pooler_mode": schema.StringAttribute{
+								MarkdownDescription: "Connection pooler mode of the endpoint. Either `transaction` or `session`. **Default** `transaction`.",
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString("transaction"),
+								Validators: []validator.String{
+									stringvalidator.OneOf("transaction", "session"),
+								},
+							},
+							"pg_settings": schema.MapAttribute{
+								MarkdownDescription: "Postgres settings of the endpoint. Only the settings given here are tracked, settings added by Neon are ignored.",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
 						},
 					},
 				},
@@ -348,29 +832,66 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 	}
 }
 
-func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
+func (r *ProjectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var keepDefaultDatabase, keepDefaultRole types.Bool
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("keep_default_database"), &keepDefaultDatabase)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("keep_default_role"), &keepDefaultRole)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
-
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+	if keepDefaultDatabase.ValueBool() && !keepDefaultRole.IsUnknown() && !keepDefaultRole.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("keep_default_role"),
+			"Invalid Default Role",
+			"`keep_default_role` must be true when `keep_default_database` is true, since the default database is owned by the default role.",
 		)
+	}
+
+	var endpoint types.Object
 
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("branch").AtName("endpoint"), &endpoint)...)
+
+	if resp.Diagnostics.HasError() || endpoint.IsNull() || endpoint.IsUnknown() {
 		return
 	}
 
-	r.client = client
-}
+	var endpointData *ProjectResourceBranchEndpointModel
 
-func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data *ProjectResourceModel
-	var allowedIpsData *ProjectResourceAllowedIpsModel
+	resp.Diagnostics.Append(endpoint.As(ctx, &endpointData, basetypes.ObjectAsOptions{})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateComputeSize(endpointData.MinCu, endpointData.MaxCu, path.Root("branch").AtName("endpoint"))...)
+}
+
+func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ProjectResourceModel
+	var allowedIpsData *ProjectResourceAllowedIpsModel
 	var branchData *ProjectResourceBranchModel
 	var branchEndpointData *ProjectResourceBranchEndpointModel
 
@@ -386,7 +907,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 			RegionId:                data.RegionId.ValueString(),
 			OrgId:                   data.OrgId.ValueStringPointer(),
 			PgVersion:               data.PgVersion.ValueInt64(),
-			StorePasswords:          true,
+			StorePasswords:          data.StorePasswords.ValueBool(),
 			HistoryRetentionSeconds: data.HistoryRetention.ValueInt64(),
 		},
 	}
@@ -401,6 +922,35 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		Name: branchData.Name.ValueString(),
 	}
 
+	roles, diags := projectSetElements[ProjectResourceRoleModel](ctx, data.Roles)
+
+	resp.Diagnostics.Append(diags...)
+
+	databases, diags := projectSetElements[ProjectResourceDatabaseModel](ctx, data.Databases)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Project creation takes one role and database, so the first inline ones are created with
+	// the project instead of the defaults when those are not kept.
+	if !data.KeepDefaultRole.ValueBool() && !data.KeepDefaultDatabase.ValueBool() {
+		databaseIdx := slices.IndexFunc(databases, func(database ProjectResourceDatabaseModel) bool {
+			return slices.ContainsFunc(roles, func(role ProjectResourceRoleModel) bool {
+				return role.Name.Equal(database.OwnerName)
+			})
+		})
+
+		if databaseIdx != -1 {
+			input.Project.Branch.RoleName = databases[databaseIdx].OwnerName.ValueString()
+			input.Project.Branch.DatabaseName = databases[databaseIdx].Name.ValueString()
+		} else if len(roles) > 0 {
+			input.Project.Branch.RoleName = roles[0].Name.ValueString()
+		}
+	}
+
 	resp.Diagnostics.Append(branchData.Endpoint.As(ctx, &branchEndpointData, basetypes.ObjectAsOptions{})...)
 
 	if resp.Diagnostics.HasError() {
@@ -413,6 +963,14 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		SuspendTimeoutSeconds: branchEndpointData.SuspendTimeout.ValueInt64(),
 	}
 
+	input.Project.Provisioner = branchEndpointData.ComputeProvisioner.ValueString()
+
+	resp.Diagnostics.Append(branchEndpointData.PgSettings.ElementsAs(ctx, &input.Project.DefaultEndpointSettings.PgSettings, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(data.AllowedIps.As(ctx, &allowedIpsData, basetypes.ObjectAsOptions{})...)
 
 	if resp.Diagnostics.HasError() {
@@ -446,6 +1004,25 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 
 	tflog.Trace(ctx, "created a project")
 
+	// Do not leave a half created project behind when any of the following steps fail.
+	defer func() {
+		if !resp.Diagnostics.HasError() {
+			return
+		}
+
+		_, err := delete(r.client, fmt.Sprintf("/projects/%s", project.Project.Id))
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete project %s after failing to create it, got error: %s", project.Project.Id, err))
+		}
+	}()
+
+	resp.Diagnostics.Append(projectSetOwnBranchId(ctx, resp.Private, project.Branch.Id)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update the branch
 	if branchData.Protected.ValueBool() {
 		branch, err := branchUpdate(r.client, project.Project.Id, project.Branch.Id, BranchUpdateInput{
@@ -464,19 +1041,101 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		project.Branch = branch.Branch
 	}
 
-	// Delete the default database.
-	err = databaseDelete(r.client, project.Project.Id, project.Branch.Id, project.Databases[0].Name)
+	// Project creation does not take these settings for the endpoint, so set them on it explicitly
+	if !branchEndpointData.PgSettings.IsNull() || branchEndpointData.PoolerEnabled.ValueBool() || branchEndpointData.PoolerMode.ValueString() != project.Endpoints[0].PoolerMode {
+		endpointInput := EndpointUpdateInput{
+			Endpoint: EndpointUpdateInputEndpoint{
+				AutoscalingLimitMinCu: project.Endpoints[0].AutoscalingLimitMinCu,
+				AutoscalingLimitMaxCu: project.Endpoints[0].AutoscalingLimitMaxCu,
+				SuspendTimeoutSeconds: project.Endpoints[0].SuspendTimeoutSeconds,
+				PoolerEnabled:         branchEndpointData.PoolerEnabled.ValueBool(),
+				PoolerMode:            branchEndpointData.PoolerMode.ValueString(),
+			},
+		}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete default database, got error: %s", err))
-		return
+		if !branchEndpointData.PgSettings.IsNull() {
+			endpointInput.Endpoint.Settings = &EndpointSettings{
+				PgSettings: input.Project.DefaultEndpointSettings.PgSettings,
+			}
+		}
+
+		endpoint, err := endpointUpdate(r.client, project.Project.Id, project.Endpoints[0].Id, endpointInput)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update endpoint, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, "updated endpoint settings")
+
+		project.Endpoints[0] = endpoint.Endpoint
+	}
+
+	data.DefaultDatabaseName = types.StringNull()
+	data.DefaultRoleName = types.StringNull()
+	data.DefaultRolePassword = types.StringNull()
+
+	// Delete the default database unless it is kept or inline.
+	if len(project.Databases) > 0 && project.Databases[0].Name != input.Project.Branch.DatabaseName {
+		if data.KeepDefaultDatabase.ValueBool() {
+			data.DefaultDatabaseName = types.StringValue(project.Databases[0].Name)
+		} else {
+			err = databaseDelete(r.client, project.Project.Id, project.Branch.Id, project.Databases[0].Name)
+
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete default database, got error: %s", err))
+				return
+			}
+		}
 	}
 
-	// Delete the default role.
-	err = roleDelete(r.client, project.Project.Id, project.Branch.Id, project.Roles[0].Name)
+	// Delete the default role unless it is kept or inline.
+	if len(project.Roles) > 0 && project.Roles[0].Name != input.Project.Branch.RoleName {
+		if data.KeepDefaultRole.ValueBool() {
+			data.DefaultRoleName = types.StringValue(project.Roles[0].Name)
+			data.DefaultRolePassword = types.StringValue(project.Roles[0].Password)
+		} else {
+			err = roleDelete(r.client, project.Project.Id, project.Branch.Id, project.Roles[0].Name)
+
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete default role, got error: %s", err))
+				return
+			}
+		}
+	}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete default role, got error: %s", err))
+	// The inline role and database created with the project already exist, the others are created now.
+	created := &ProjectResourceModel{
+		Roles:         types.SetNull(types.ObjectType{AttrTypes: projectRoleAttrTypes}),
+		RolePasswords: types.MapNull(types.StringType),
+		Databases:     types.SetNull(types.ObjectType{AttrTypes: projectDatabaseAttrTypes}),
+		Branches:      types.SetNull(types.ObjectType{AttrTypes: projectExtraBranchAttrTypes}),
+	}
+
+	if input.Project.Branch.RoleName != "" && len(project.Roles) > 0 {
+		created.Roles = types.SetValueMust(types.ObjectType{AttrTypes: projectRoleAttrTypes}, []attr.Value{
+			types.ObjectValueMust(projectRoleAttrTypes, map[string]attr.Value{
+				"name": types.StringValue(project.Roles[0].Name),
+			}),
+		})
+
+		created.RolePasswords = types.MapValueMust(types.StringType, map[string]attr.Value{
+			project.Roles[0].Name: types.StringValue(project.Roles[0].Password),
+		})
+	}
+
+	if input.Project.Branch.DatabaseName != "" && len(project.Databases) > 0 {
+		created.Databases = types.SetValueMust(types.ObjectType{AttrTypes: projectDatabaseAttrTypes}, []attr.Value{
+			types.ObjectValueMust(projectDatabaseAttrTypes, map[string]attr.Value{
+				"name":       types.StringValue(project.Databases[0].Name),
+				"owner_name": types.StringValue(project.Databases[0].OwnerName),
+			}),
+		})
+	}
+
+	resp.Diagnostics.Append(r.applyInline(ctx, project.Project.Id, project.Branch.Id, data, created)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -485,6 +1144,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 	data.PlatformId = types.StringValue(project.Project.PlatformId)
 	data.RegionId = types.StringValue(project.Project.RegionId)
 	data.PgVersion = types.Int64Value(project.Project.PgVersion)
+	data.StorePasswords = types.BoolValue(project.Project.StorePasswords)
 	data.HistoryRetention = types.Int64Value(project.Project.HistoryRetentionSeconds)
 
 	if project.Project.OrgId != "" {
@@ -510,18 +1170,31 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 	data.Branch = types.ObjectValueMust(
 		branchAttrTypes,
 		map[string]attr.Value{
-			"id":        types.StringValue(project.Branch.Id),
-			"name":      types.StringValue(project.Branch.Name),
-			"protected": types.BoolValue(project.Branch.Protected),
+			"id":            types.StringValue(project.Branch.Id),
+			"name":          types.StringValue(project.Branch.Name),
+			"protected":     types.BoolValue(project.Branch.Protected),
+			"current_state": types.StringValue(project.Branch.CurrentState),
+			"logical_size":  types.Int64Value(project.Branch.LogicalSize),
+			"created_at":    types.StringValue(project.Branch.CreatedAt),
+			"updated_at":    types.StringValue(project.Branch.UpdatedAt),
+			"last_reset_at": types.StringPointerValue(project.Branch.LastResetAt),
 			"endpoint": types.ObjectValueMust(
 				branchEndpointAttrTypes,
 				map[string]attr.Value{
 					"id":                  types.StringValue(project.Endpoints[0].Id),
 					"host":                types.StringValue(project.Endpoints[0].Host),
+					"pooler_host":         types.StringValue(endpointPoolerHost(project.Endpoints[0].Host)),
+					"proxy_host":          types.StringValue(project.Endpoints[0].ProxyHost),
+					"region_id":           types.StringValue(project.Endpoints[0].RegionId),
+					"created_at":          types.StringValue(project.Endpoints[0].CreatedAt),
+					"last_active":         types.StringPointerValue(project.Endpoints[0].LastActive),
 					"min_cu":              types.Float64Value(project.Endpoints[0].AutoscalingLimitMinCu),
 					"max_cu":              types.Float64Value(project.Endpoints[0].AutoscalingLimitMaxCu),
 					"compute_provisioner": types.StringValue(project.Endpoints[0].ComputeProvisioner),
 					"suspend_timeout":     types.Int64Value(project.Endpoints[0].SuspendTimeoutSeconds),
+					"pg_settings":         pgSettingsValue(pgSettingsFrom(projectBranchEndpoint(data.Branch)), project.Endpoints[0].Settings.PgSettings),
+					"pooler_enabled":      types.BoolValue(project.Endpoints[0].PoolerEnabled),
+					"pooler_mode":         types.StringValue(project.Endpoints[0].PoolerMode),
 				},
 			),
 		},
@@ -564,17 +1237,53 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	ownBranchId, diags := projectOwnBranchId(ctx, req.Private)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Not present when importing.
+	if ownBranchId == "" {
+		ownBranchId = branch.Id
+
+		resp.Diagnostics.Append(projectSetOwnBranchId(ctx, resp.Private, ownBranchId)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Name is only missing when importing.
+	resp.Diagnostics.Append(r.readInline(ctx, project.Project.Id, ownBranchId, data, project.Project.StorePasswords)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	data.Id = types.StringValue(project.Project.Id)
 	data.Name = types.StringValue(project.Project.Name)
 	data.PlatformId = types.StringValue(project.Project.PlatformId)
 	data.RegionId = types.StringValue(project.Project.RegionId)
 	data.PgVersion = types.Int64Value(project.Project.PgVersion)
+	data.StorePasswords = types.BoolValue(project.Project.StorePasswords)
 	data.HistoryRetention = types.Int64Value(project.Project.HistoryRetentionSeconds)
 
 	if project.Project.OrgId != "" {
 		data.OrgId = types.StringValue(project.Project.OrgId)
 	}
 
+	// Not present when importing.
+	if data.KeepDefaultDatabase.IsNull() {
+		data.KeepDefaultDatabase = types.BoolValue(false)
+	}
+
+	if data.KeepDefaultRole.IsNull() {
+		data.KeepDefaultRole = types.BoolValue(false)
+	}
+
 	var allowed []attr.Value
 
 	for _, ip := range project.Project.Settings.AllowedIps.Ips {
@@ -594,18 +1303,31 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	data.Branch = types.ObjectValueMust(
 		branchAttrTypes,
 		map[string]attr.Value{
-			"id":        types.StringValue(branch.Id),
-			"name":      types.StringValue(branch.Name),
-			"protected": types.BoolValue(branch.Protected),
+			"id":            types.StringValue(branch.Id),
+			"name":          types.StringValue(branch.Name),
+			"protected":     types.BoolValue(branch.Protected),
+			"current_state": types.StringValue(branch.CurrentState),
+			"logical_size":  types.Int64Value(branch.LogicalSize),
+			"created_at":    types.StringValue(branch.CreatedAt),
+			"updated_at":    types.StringValue(branch.UpdatedAt),
+			"last_reset_at": types.StringPointerValue(branch.LastResetAt),
 			"endpoint": types.ObjectValueMust(
 				branchEndpointAttrTypes,
 				map[string]attr.Value{
 					"id":                  types.StringValue(endpoint.Id),
 					"host":                types.StringValue(endpoint.Host),
+					"pooler_host":         types.StringValue(endpointPoolerHost(endpoint.Host)),
+					"proxy_host":          types.StringValue(endpoint.ProxyHost),
+					"region_id":           types.StringValue(endpoint.RegionId),
+					"created_at":          types.StringValue(endpoint.CreatedAt),
+					"last_active":         types.StringPointerValue(endpoint.LastActive),
 					"min_cu":              types.Float64Value(endpoint.AutoscalingLimitMinCu),
 					"max_cu":              types.Float64Value(endpoint.AutoscalingLimitMaxCu),
 					"compute_provisioner": types.StringValue(endpoint.ComputeProvisioner),
 					"suspend_timeout":     types.Int64Value(endpoint.SuspendTimeoutSeconds),
+					"pg_settings":         pgSettingsValue(pgSettingsFrom(projectBranchEndpoint(data.Branch)), endpoint.Settings.PgSettings),
+					"pooler_enabled":      types.BoolValue(endpoint.PoolerEnabled),
+					"pooler_mode":         types.StringValue(endpoint.PoolerMode),
 				},
 			),
 		},
@@ -617,11 +1339,7 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data *ProjectResourceModel
 	var allowedIpsData *ProjectResourceAllowedIpsModel
-	var branchData *ProjectResourceBranchModel
-	var branchEndpointData *ProjectResourceBranchEndpointModel
-
 	var state *ProjectResourceModel
-	var branchState *ProjectResourceBranchModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -674,23 +1392,131 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	tflog.Trace(ctx, "updated a project")
 
-	resp.Diagnostics.Append(data.Branch.As(ctx, &branchData, basetypes.ObjectAsOptions{})...)
+	ownBranchId, diags := projectOwnBranchId(ctx, req.Private)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// When another branch is set as default, the branch settings follow it and are managed by its neon_branch.
+	if projectFollowsDefault(ownBranchId, state.Branch) {
+		data.Branch = state.Branch
+	} else {
+		resp.Diagnostics.Append(r.updateDefaultBranch(ctx, data, state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ownBranchId = projectBranchId(data.Branch)
+	}
+
+	resp.Diagnostics.Append(r.dropDefaults(ctx, data.Id.ValueString(), ownBranchId, data, state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(state.Branch.As(ctx, &branchState, basetypes.ObjectAsOptions{})...)
+	resp.Diagnostics.Append(r.applyInline(ctx, data.Id.ValueString(), ownBranchId, data, state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	branch := Branch{
-		Id:   branchState.Id.ValueString(),
-		Name: branchState.Name.ValueString(),
+	data.Id = types.StringValue(project.Project.Id)
+	data.Name = types.StringValue(project.Project.Name)
+	data.PlatformId = types.StringValue(project.Project.PlatformId)
+	data.RegionId = types.StringValue(project.Project.RegionId)
+	data.PgVersion = types.Int64Value(project.Project.PgVersion)
+	data.StorePasswords = types.BoolValue(project.Project.StorePasswords)
+	data.HistoryRetention = types.Int64Value(project.Project.HistoryRetentionSeconds)
+
+	if project.Project.OrgId != "" {
+		data.OrgId = types.StringValue(project.Project.OrgId)
 	}
 
+	var allowed []attr.Value
+
+	for _, ip := range project.Project.Settings.AllowedIps.Ips {
+		allowed = append(allowed, types.StringValue(ip))
+	}
+
+	data.AllowedIps = types.ObjectValueMust(
+		allowedIpsAttrTypes,
+		map[string]attr.Value{
+			"ips":                     types.ListValueMust(types.StringType, allowed),
+			"protected_branches_only": types.BoolValue(project.Project.Settings.AllowedIps.ProtectedBranchesOnly),
+		},
+	)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dropDefaults deletes the default database and role created with the project
+// once they are no longer kept. The database goes first as the role owns it.
+func (r *ProjectResource) dropDefaults(ctx context.Context, projectId string, branchId string, data *ProjectResourceModel, state *ProjectResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !state.DefaultDatabaseName.IsNull() && !data.KeepDefaultDatabase.ValueBool() {
+		err := databaseDelete(r.client, projectId, branchId, state.DefaultDatabaseName.ValueString())
+
+		if err != nil && !isNotFound(err) {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete default database, got error: %s", err))
+			return diags
+		}
+
+		tflog.Trace(ctx, "deleted default database")
+
+		data.DefaultDatabaseName = types.StringNull()
+	}
+
+	if !state.DefaultRoleName.IsNull() && !data.KeepDefaultRole.ValueBool() {
+		err := roleDelete(r.client, projectId, branchId, state.DefaultRoleName.ValueString())
+
+		if err != nil && !isNotFound(err) {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete default role, got error: %s", err))
+			return diags
+		}
+
+		tflog.Trace(ctx, "deleted default role")
+
+		data.DefaultRoleName = types.StringNull()
+		data.DefaultRolePassword = types.StringNull()
+	}
+
+	return diags
+}
+
+// updateDefaultBranch applies the branch settings to the default branch and its endpoint.
+func (r *ProjectResource) updateDefaultBranch(ctx context.Context, data *ProjectResourceModel, state *ProjectResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var branchData *ProjectResourceBranchModel
+	var branchEndpointData *ProjectResourceBranchEndpointModel
+	var branchState *ProjectResourceBranchModel
+
+	diags.Append(data.Branch.As(ctx, &branchData, basetypes.ObjectAsOptions{})...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(state.Branch.As(ctx, &branchState, basetypes.ObjectAsOptions{})...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	current, err := branchGet(r.client, data.Id.ValueString(), branchState.Id.ValueString())
+
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read branch, got error: %s", err))
+		return diags
+	}
+
+	branch := current.Branch
+
 	branchInput := BranchUpdateInput{
 		Branch: BranchUpdateInputBranch{},
 	}
@@ -708,8 +1534,8 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 		branchOutput, err := branchUpdate(r.client, data.Id.ValueString(), branchData.Id.ValueString(), branchInput)
 
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update branch, got error: %s", err))
-			return
+			diags.AddError("Client Error", fmt.Sprintf("Unable to update branch, got error: %s", err))
+			return diags
 		}
 
 		tflog.Trace(ctx, "updated a branch")
@@ -717,10 +1543,10 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 		branch = branchOutput.Branch
 	}
 
-	resp.Diagnostics.Append(branchData.Endpoint.As(ctx, &branchEndpointData, basetypes.ObjectAsOptions{})...)
+	diags.Append(branchData.Endpoint.As(ctx, &branchEndpointData, basetypes.ObjectAsOptions{})...)
 
-	if resp.Diagnostics.HasError() {
-		return
+	if diags.HasError() {
+		return diags
 	}
 
 	endpointInput := EndpointUpdateInput{
@@ -728,64 +1554,67 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 			AutoscalingLimitMinCu: branchEndpointData.MinCu.ValueFloat64(),
 			AutoscalingLimitMaxCu: branchEndpointData.MaxCu.ValueFloat64(),
 			SuspendTimeoutSeconds: branchEndpointData.SuspendTimeout.ValueInt64(),
+			PoolerEnabled:         branchEndpointData.PoolerEnabled.ValueBool(),
+			PoolerMode:            branchEndpointData.PoolerMode.ValueString(),
+			Provisioner:           branchEndpointData.ComputeProvisioner.ValueString(),
 		},
 	}
 
-	endpoint, err := endpointUpdate(r.client, data.Id.ValueString(), branchEndpointData.Id.ValueString(), endpointInput)
-
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update endpoint, got error: %s", err))
-		return
-	}
+	settings, d := endpointSettingsInput(ctx, branchEndpointData.PgSettings, pgSettingsFrom(projectBranchEndpoint(state.Branch)))
 
-	tflog.Trace(ctx, "updated an endpoint")
+	diags.Append(d...)
 
-	data.Id = types.StringValue(project.Project.Id)
-	data.Name = types.StringValue(project.Project.Name)
-	data.PlatformId = types.StringValue(project.Project.PlatformId)
-	data.RegionId = types.StringValue(project.Project.RegionId)
-	data.PgVersion = types.Int64Value(project.Project.PgVersion)
-	data.HistoryRetention = types.Int64Value(project.Project.HistoryRetentionSeconds)
-
-	if project.Project.OrgId != "" {
-		data.OrgId = types.StringValue(project.Project.OrgId)
+	if diags.HasError() {
+		return diags
 	}
 
-	var allowed []attr.Value
+	endpointInput.Endpoint.Settings = settings
 
-	for _, ip := range project.Project.Settings.AllowedIps.Ips {
-		allowed = append(allowed, types.StringValue(ip))
+	endpoint, err := endpointUpdate(r.client, data.Id.ValueString(), branchEndpointData.Id.ValueString(), endpointInput)
+
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update endpoint, got error: %s", err))
+		return diags
 	}
 
-	data.AllowedIps = types.ObjectValueMust(
-		allowedIpsAttrTypes,
-		map[string]attr.Value{
-			"ips":                     types.ListValueMust(types.StringType, allowed),
-			"protected_branches_only": types.BoolValue(project.Project.Settings.AllowedIps.ProtectedBranchesOnly),
-		},
-	)
+	tflog.Trace(ctx, "updated an endpoint")
 
+	// The state and timestamps of the branch and its endpoint change on their own, so the
+	// planned values are kept and the next read refreshes them.
 	data.Branch = types.ObjectValueMust(
 		branchAttrTypes,
 		map[string]attr.Value{
-			"id":        types.StringValue(branch.Id),
-			"name":      types.StringValue(branch.Name),
-			"protected": types.BoolValue(branch.Protected),
+			"id":            types.StringValue(branch.Id),
+			"name":          types.StringValue(branch.Name),
+			"protected":     types.BoolValue(branch.Protected),
+			"current_state": plannedOr(branchData.CurrentState, types.StringValue(branch.CurrentState)),
+			"logical_size":  plannedOr(branchData.LogicalSize, types.Int64Value(branch.LogicalSize)),
+			"created_at":    types.StringValue(branch.CreatedAt),
+			"updated_at":    plannedOr(branchData.UpdatedAt, types.StringValue(branch.UpdatedAt)),
+			"last_reset_at": plannedOr(branchData.LastResetAt, types.StringPointerValue(branch.LastResetAt)),
 			"endpoint": types.ObjectValueMust(
 				branchEndpointAttrTypes,
 				map[string]attr.Value{
 					"id":                  types.StringValue(endpoint.Endpoint.Id),
 					"host":                types.StringValue(endpoint.Endpoint.Host),
+					"pooler_host":         types.StringValue(endpointPoolerHost(endpoint.Endpoint.Host)),
+					"proxy_host":          types.StringValue(endpoint.Endpoint.ProxyHost),
+					"region_id":           types.StringValue(endpoint.Endpoint.RegionId),
+					"created_at":          types.StringValue(endpoint.Endpoint.CreatedAt),
+					"last_active":         plannedOr(branchEndpointData.LastActive, types.StringPointerValue(endpoint.Endpoint.LastActive)),
 					"min_cu":              types.Float64Value(endpoint.Endpoint.AutoscalingLimitMinCu),
 					"max_cu":              types.Float64Value(endpoint.Endpoint.AutoscalingLimitMaxCu),
 					"compute_provisioner": types.StringValue(endpoint.Endpoint.ComputeProvisioner),
 					"suspend_timeout":     types.Int64Value(endpoint.Endpoint.SuspendTimeoutSeconds),
+					"pg_settings":         pgSettingsValue(pgSettingsFrom(projectBranchEndpoint(data.Branch)), endpoint.Endpoint.Settings.PgSettings),
+					"pooler_enabled":      types.BoolValue(endpoint.Endpoint.PoolerEnabled),
+					"pooler_mode":         types.StringValue(endpoint.Endpoint.PoolerMode),
 				},
 			),
 		},
 	)
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return diags
 }
 
 func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -811,6 +1640,79 @@ func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportSt
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+func projectBranchEndpoint(branch types.Object) types.Object {
+	if value, ok := branch.Attributes()["endpoint"].(types.Object); ok {
+		return value
+	}
+
+	return types.ObjectNull(branchEndpointAttrTypes)
+}
+
+const projectOwnBranchKey = "own_branch_id"
+
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// projectOwnBranchId returns the id of the branch the project was created with, kept in private state.
+func projectOwnBranchId(ctx context.Context, private privateStateGetter) (string, diag.Diagnostics) {
+	var id string
+
+	value, diags := private.GetKey(ctx, projectOwnBranchKey)
+
+	if diags.HasError() || len(value) == 0 {
+		return id, diags
+	}
+
+	err := json.Unmarshal(value, &id)
+
+	if err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to read branch of the project from private state, got error: %s", err))
+	}
+
+	return id, diags
+}
+
+func projectSetOwnBranchId(ctx context.Context, private privateStateSetter, id string) diag.Diagnostics {
+	value, err := json.Marshal(id)
+
+	if err != nil {
+		var diags diag.Diagnostics
+
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to save branch of the project to private state, got error: %s", err))
+
+		return diags
+	}
+
+	return private.SetKey(ctx, projectOwnBranchKey, value)
+}
+
+// projectFollowsDefault reports whether the default branch is no longer the branch the project was created with.
+func projectFollowsDefault(ownBranchId string, branch types.Object) bool {
+	return ownBranchId != "" && !branch.IsNull() && !branch.IsUnknown() && projectBranchId(branch) != ownBranchId
+}
+
+func projectBranchId(branch types.Object) string {
+	if value, ok := branch.Attributes()["id"].(types.String); ok {
+		return value.ValueString()
+	}
+
+	return ""
+}
+
+// plannedOr returns the planned value when it is known, otherwise the actual one.
+func plannedOr(planned attr.Value, actual attr.Value) attr.Value {
+	if planned.IsUnknown() {
+		return actual
+	}
+
+	return planned
+}
+
 func readDefaultBranch(client *http.Client, projectId string) (Branch, error) {
 	var branch Branch
 
@@ -826,5 +1728,401 @@ func readDefaultBranch(client *http.Client, projectId string) (Branch, error) {
 		return branch.Default
 	})
 
+	if branchIdx == -1 {
+		return branch, fmt.Errorf("no default branch found for project %s", projectId)
+	}
+
 	return branches.Branches[branchIdx], nil
 }
+
+// applyInline brings the inline roles, databases and branches from state to plan. Sets which
+// are null in the plan are not managed, so nothing is created or deleted for them.
+func (r *ProjectResource) applyInline(ctx context.Context, projectId string, branchId string, plan *ProjectResourceModel, state *ProjectResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	roles, d := projectSetElements[ProjectResourceRoleModel](ctx, plan.Roles)
+	diags.Append(d...)
+
+	currentRoles, d := projectSetElements[ProjectResourceRoleModel](ctx, state.Roles)
+	diags.Append(d...)
+
+	databases, d := projectSetElements[ProjectResourceDatabaseModel](ctx, plan.Databases)
+	diags.Append(d...)
+
+	currentDatabases, d := projectSetElements[ProjectResourceDatabaseModel](ctx, state.Databases)
+	diags.Append(d...)
+
+	branches, d := projectSetElements[ProjectResourceExtraBranchModel](ctx, plan.Branches)
+	diags.Append(d...)
+
+	currentBranches, d := projectSetElements[ProjectResourceExtraBranchModel](ctx, state.Branches)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	passwords := map[string]attr.Value{}
+
+	for name, password := range state.RolePasswords.Elements() {
+		passwords[name] = password
+	}
+
+	// Once a set starts being managed, for example after import, objects that already exist are
+	// adopted instead of created. They are never deleted for not being in the set.
+	existingRoles := currentRoles
+	existingDatabases := currentDatabases
+	existingBranches := currentBranches
+
+	if state.Roles.IsNull() && !plan.Roles.IsNull() {
+		list, err := roleList(r.client, projectId, branchId)
+
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read roles, got error: %s", err))
+			return diags
+		}
+
+		for _, role := range list.Roles {
+			existingRoles = append(existingRoles, ProjectResourceRoleModel{Name: types.StringValue(role.Name)})
+
+			// The password can only be revealed when the project stores passwords
+			if password, err := roleRevealPassword(r.client, projectId, branchId, role.Name); err == nil {
+				passwords[role.Name] = types.StringValue(password.Password)
+			}
+		}
+	}
+
+	if state.Databases.IsNull() && !plan.Databases.IsNull() {
+		list, err := databaseList(r.client, projectId, branchId)
+
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read databases, got error: %s", err))
+			return diags
+		}
+
+		for _, database := range list.Databases {
+			existingDatabases = append(existingDatabases, ProjectResourceDatabaseModel{
+				Name:      types.StringValue(database.Name),
+				OwnerName: types.StringValue(database.OwnerName),
+			})
+		}
+	}
+
+	if state.Branches.IsNull() && !plan.Branches.IsNull() {
+		list, err := branchList(r.client, projectId)
+
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read branches, got error: %s", err))
+			return diags
+		}
+
+		for _, branch := range list.Branches {
+			existingBranches = append(existingBranches, ProjectResourceExtraBranchModel{Name: types.StringValue(branch.Name)})
+		}
+	}
+
+	// Roles are created first and deleted last as they own the databases.
+	if !plan.Roles.IsNull() {
+		for _, role := range roles {
+			if slices.ContainsFunc(existingRoles, func(current ProjectResourceRoleModel) bool {
+				return current.Name.Equal(role.Name)
+			}) {
+				continue
+			}
+
+			created, err := roleCreate(r.client, projectId, branchId, RoleCreateInput{
+				Role: RoleCreateInputRole{
+					Name: role.Name.ValueString(),
+				},
+			})
+
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to create role %s, got error: %s", role.Name.ValueString(), err))
+				return diags
+			}
+
+			passwords[created.Role.Name] = types.StringValue(created.Role.Password)
+
+			tflog.Trace(ctx, "created an inline role")
+		}
+	}
+
+	if !plan.Databases.IsNull() {
+		for _, database := range databases {
+			currentIdx := slices.IndexFunc(existingDatabases, func(current ProjectResourceDatabaseModel) bool {
+				return current.Name.Equal(database.Name)
+			})
+
+			if currentIdx != -1 && existingDatabases[currentIdx].OwnerName.Equal(database.OwnerName) {
+				continue
+			}
+
+			var err error
+
+			if currentIdx == -1 {
+				_, err = databaseCreate(r.client, projectId, branchId, DatabaseCreateInput{
+					Database: DatabaseCreateInputDatabase{
+						Name:      database.Name.ValueString(),
+						OwnerName: database.OwnerName.ValueString(),
+					},
+				})
+			} else {
+				_, err = databaseUpdate(r.client, projectId, branchId, database.Name.ValueString(), DatabaseUpdateInput{
+					Database: DatabaseUpdateInputDatabase{
+						Name:      database.Name.ValueString(),
+						OwnerName: database.OwnerName.ValueString(),
+					},
+				})
+			}
+
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to save database %s, got error: %s", database.Name.ValueString(), err))
+				return diags
+			}
+
+			tflog.Trace(ctx, "saved an inline database")
+		}
+
+		for _, current := range currentDatabases {
+			if slices.ContainsFunc(databases, func(database ProjectResourceDatabaseModel) bool {
+				return database.Name.Equal(current.Name)
+			}) {
+				continue
+			}
+
+			err := databaseDelete(r.client, projectId, branchId, current.Name.ValueString())
+
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete database %s, got error: %s", current.Name.ValueString(), err))
+				return diags
+			}
+
+			tflog.Trace(ctx, "deleted an inline database")
+		}
+	}
+
+	if !plan.Roles.IsNull() {
+		for _, current := range currentRoles {
+			if slices.ContainsFunc(roles, func(role ProjectResourceRoleModel) bool {
+				return role.Name.Equal(current.Name)
+			}) {
+				continue
+			}
+
+			err := roleDelete(r.client, projectId, branchId, current.Name.ValueString())
+
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete role %s, got error: %s", current.Name.ValueString(), err))
+				return diags
+			}
+
+			tflog.Trace(ctx, "deleted an inline role")
+		}
+	}
+
+	if !plan.Branches.IsNull() {
+		for _, branch := range branches {
+			if slices.ContainsFunc(existingBranches, func(current ProjectResourceExtraBranchModel) bool {
+				return current.Name.Equal(branch.Name)
+			}) {
+				continue
+			}
+
+			created, err := branchCreate(r.client, projectId, BranchCreateInput{
+				Branch: BranchCreateInputBranch{
+					Name:     branch.Name.ValueString(),
+					ParentId: branchId,
+				},
+			})
+
+			if err == nil {
+				_, err = branchWaitReady(ctx, r.client, projectId, created.Branch.Id)
+			}
+
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to create branch %s, got error: %s", branch.Name.ValueString(), err))
+				return diags
+			}
+
+			tflog.Trace(ctx, "created an inline branch")
+		}
+
+		for _, current := range currentBranches {
+			if slices.ContainsFunc(branches, func(branch ProjectResourceExtraBranchModel) bool {
+				return branch.Name.Equal(current.Name)
+			}) {
+				continue
+			}
+
+			branch, err := branchByName(r.client, projectId, current.Name.ValueString())
+
+			if err == nil {
+				err = branchDelete(r.client, projectId, branch.Id)
+			}
+
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete branch %s, got error: %s", current.Name.ValueString(), err))
+				return diags
+			}
+
+			tflog.Trace(ctx, "deleted an inline branch")
+		}
+	}
+
+	plan.RolePasswords = types.MapNull(types.StringType)
+
+	if !plan.Roles.IsNull() {
+		planned := map[string]attr.Value{}
+
+		for _, role := range roles {
+			if password, ok := passwords[role.Name.ValueString()]; ok {
+				planned[role.Name.ValueString()] = password
+			}
+		}
+
+		plan.RolePasswords = types.MapValueMust(types.StringType, planned)
+	}
+
+	return diags
+}
+
+// readInline refreshes the inline roles, databases and branches which are managed. Only the names
+// in state are tracked, so objects managed elsewhere are left alone. Import leaves the sets unset, as
+// it can not tell which objects are managed elsewhere, and they are adopted once declared.
+func (r *ProjectResource) readInline(ctx context.Context, projectId string, branchId string, data *ProjectResourceModel, storePasswords bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.Roles.IsNull() {
+		currentRoles, d := projectSetElements[ProjectResourceRoleModel](ctx, data.Roles)
+		diags.Append(d...)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		roles, err := roleList(r.client, projectId, branchId)
+
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read roles, got error: %s", err))
+			return diags
+		}
+
+		var values []attr.Value
+		passwords := map[string]attr.Value{}
+
+		for _, role := range roles.Roles {
+			managed := slices.ContainsFunc(currentRoles, func(current ProjectResourceRoleModel) bool {
+				return current.Name.ValueString() == role.Name
+			})
+
+			if !managed {
+				continue
+			}
+
+			values = append(values, types.ObjectValueMust(projectRoleAttrTypes, map[string]attr.Value{
+				"name": types.StringValue(role.Name),
+			}))
+
+			// Passwords can only be revealed when the project stores them, otherwise the
+			// password from when the role was created is kept
+			if storePasswords {
+				password, err := roleRevealPassword(r.client, projectId, branchId, role.Name)
+
+				if err != nil {
+					diags.AddError("Client Error", fmt.Sprintf("Unable to read password of role %s, got error: %s", role.Name, err))
+					return diags
+				}
+
+				passwords[role.Name] = types.StringValue(password.Password)
+			} else if password, ok := data.RolePasswords.Elements()[role.Name]; ok {
+				passwords[role.Name] = password
+			}
+		}
+
+		data.Roles = types.SetValueMust(types.ObjectType{AttrTypes: projectRoleAttrTypes}, values)
+		data.RolePasswords = types.MapValueMust(types.StringType, passwords)
+	}
+
+	if !data.Databases.IsNull() {
+		currentDatabases, d := projectSetElements[ProjectResourceDatabaseModel](ctx, data.Databases)
+		diags.Append(d...)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		databases, err := databaseList(r.client, projectId, branchId)
+
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read databases, got error: %s", err))
+			return diags
+		}
+
+		var values []attr.Value
+
+		for _, database := range databases.Databases {
+			managed := slices.ContainsFunc(currentDatabases, func(current ProjectResourceDatabaseModel) bool {
+				return current.Name.ValueString() == database.Name
+			})
+
+			if !managed {
+				continue
+			}
+
+			values = append(values, types.ObjectValueMust(projectDatabaseAttrTypes, map[string]attr.Value{
+				"name":       types.StringValue(database.Name),
+				"owner_name": types.StringValue(database.OwnerName),
+			}))
+		}
+
+		data.Databases = types.SetValueMust(types.ObjectType{AttrTypes: projectDatabaseAttrTypes}, values)
+	}
+
+	if !data.Branches.IsNull() {
+		currentBranches, d := projectSetElements[ProjectResourceExtraBranchModel](ctx, data.Branches)
+		diags.Append(d...)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		branches, err := branchList(r.client, projectId)
+
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read branches, got error: %s", err))
+			return diags
+		}
+
+		var values []attr.Value
+
+		for _, branch := range branches.Branches {
+			managed := slices.ContainsFunc(currentBranches, func(current ProjectResourceExtraBranchModel) bool {
+				return current.Name.ValueString() == branch.Name
+			})
+
+			if !managed {
+				continue
+			}
+
+			values = append(values, types.ObjectValueMust(projectExtraBranchAttrTypes, map[string]attr.Value{
+				"name": types.StringValue(branch.Name),
+			}))
+		}
+
+		data.Branches = types.SetValueMust(types.ObjectType{AttrTypes: projectExtraBranchAttrTypes}, values)
+	}
+
+	return diags
+}
+
+func projectSetElements[T any](ctx context.Context, set types.Set) ([]T, diag.Diagnostics) {
+	var elements []T
+
+	if set.IsNull() || set.IsUnknown() {
+		return elements, nil
+	}
+
+	diags := set.ElementsAs(ctx, &elements, false)
+
+	return elements, diags
+}
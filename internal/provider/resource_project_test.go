@@ -6,12 +6,18 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func hostRegex(region string) *regexp.Regexp {
 	return regexp.MustCompile("^[-0-9a-z\\.]+\\." + region + "\\.aws\\.neon\\.tech$")
 }
 
+func poolerHostRegex(region string) *regexp.Regexp {
+	return regexp.MustCompile("^[-0-9a-z]+-pooler\\." + region + "\\.aws\\.neon\\.tech$")
+}
+
 func TestAccProjectResourceDefaultForUser(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -28,14 +34,23 @@ func TestAccProjectResourceDefaultForUser(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_project.test", "org_id", "org-blue-haze-97971912"),
 					resource.TestCheckResourceAttr("neon_project.test", "pg_version", "15"),
 					resource.TestCheckResourceAttr("neon_project.test", "history_retention", "86400"),
+					resource.TestCheckResourceAttr("neon_project.test", "store_passwords", "true"),
 					resource.TestCheckResourceAttr("neon_project.test", "logical_replication", "false"),
 					resource.TestCheckResourceAttr("neon_project.test", "allowed_ips.ips.#", "0"),
 					resource.TestCheckResourceAttr("neon_project.test", "allowed_ips.protected_branches_only", "false"),
 					resource.TestMatchResourceAttr("neon_project.test", "branch.id", idRegex()),
 					resource.TestCheckResourceAttr("neon_project.test", "branch.name", "main"),
 					resource.TestCheckResourceAttr("neon_project.test", "branch.protected", "false"),
+					resource.TestMatchResourceAttr("neon_project.test", "branch.current_state", regexp.MustCompile("^(init|ready)$")),
+					resource.TestMatchResourceAttr("neon_project.test", "branch.logical_size", regexp.MustCompile("^[0-9]+$")),
+					resource.TestMatchResourceAttr("neon_project.test", "branch.created_at", existRegex()),
+					resource.TestMatchResourceAttr("neon_project.test", "branch.updated_at", existRegex()),
 					resource.TestMatchResourceAttr("neon_project.test", "branch.endpoint.id", idRegex()),
 					resource.TestMatchResourceAttr("neon_project.test", "branch.endpoint.host", hostRegex("us-east-2")),
+					resource.TestMatchResourceAttr("neon_project.test", "branch.endpoint.pooler_host", poolerHostRegex("us-east-2")),
+					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.proxy_host", "us-east-2.aws.neon.tech"),
+					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.region_id", "aws-us-east-2"),
+					resource.TestMatchResourceAttr("neon_project.test", "branch.endpoint.created_at", existRegex()),
 					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.min_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.max_cu", "0.25"),
 					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.compute_provisioner", "k8s-neonvm"),
@@ -384,6 +399,197 @@ func TestAccProjectResourceNonDefault(t *testing.T) {
 	})
 }
 
+func TestAccProjectResourcePgSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectResourceConfigPgSettings("todo-app", "64MB"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_project.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.pg_settings.%", "1"),
+					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.pg_settings.work_mem", "64MB"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccProjectResourceConfigPgSettings("todo-app", "128MB"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_project.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.pg_settings.%", "1"),
+					resource.TestCheckResourceAttr("neon_project.test", "branch.endpoint.pg_settings.work_mem", "128MB"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccProjectResourceInvalidComputeSize(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Min larger than max
+			{
+				Config:      testAccProjectResourceConfigComputeSize(4, 1),
+				ExpectError: regexp.MustCompile("must be less than or equal to max_cu"),
+			},
+			// Spread larger than allowed
+			{
+				Config:      testAccProjectResourceConfigComputeSize(0.25, 16),
+				ExpectError: regexp.MustCompile("can be at most 8 compute units more than min_cu"),
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceKeepDefaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectResourceConfigKeepDefaults(true, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_project.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_project.test", "keep_default_database", "true"),
+					resource.TestCheckResourceAttr("neon_project.test", "keep_default_role", "true"),
+					resource.TestCheckResourceAttr("neon_project.test", "default_database_name", "neondb"),
+					resource.TestCheckResourceAttr("neon_project.test", "default_role_name", "neondb_owner"),
+					resource.TestMatchResourceAttr("neon_project.test", "default_role_password", existRegex()),
+				),
+			},
+			// No longer keeping the defaults deletes them in place
+			{
+				Config: testAccProjectResourceConfigKeepDefaults(false, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neon_project.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_project.test", "keep_default_database", "false"),
+					resource.TestCheckResourceAttr("neon_project.test", "keep_default_role", "false"),
+					resource.TestCheckNoResourceAttr("neon_project.test", "default_database_name"),
+					resource.TestCheckNoResourceAttr("neon_project.test", "default_role_name"),
+					resource.TestCheckNoResourceAttr("neon_project.test", "default_role_password"),
+				),
+			},
+			// The deleted defaults can not be kept again
+			{
+				Config:      testAccProjectResourceConfigKeepDefaults(true, true),
+				ExpectError: regexp.MustCompile("can only be set to true when the project is created"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccProjectResourceKeepDefaultDatabaseWithoutRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProjectResourceConfigKeepDefaults(true, false),
+				ExpectError: regexp.MustCompile("`keep_default_role` must be true"),
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceStorePasswordsChange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectResourceConfigStorePasswords(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_project.test", "store_passwords", "false"),
+				),
+			},
+			// Changing it is rejected instead of replacing the project
+			{
+				Config:      testAccProjectResourceConfigStorePasswords(true),
+				ExpectError: regexp.MustCompile("Store Passwords Cannot Change"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccProjectResourceInline(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectResourceConfigInline(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_project.test", "id", idRegex()),
+					resource.TestCheckResourceAttr("neon_project.test", "roles.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_project.test", "roles.*", map[string]string{"name": "admin"}),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_project.test", "roles.*", map[string]string{"name": "reader"}),
+					resource.TestMatchResourceAttr("neon_project.test", "role_passwords.admin", existRegex()),
+					resource.TestMatchResourceAttr("neon_project.test", "role_passwords.reader", existRegex()),
+					resource.TestCheckResourceAttr("neon_project.test", "databases.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_project.test", "databases.*", map[string]string{"name": "orders", "owner_name": "admin"}),
+					resource.TestCheckResourceAttr("neon_project.test", "branches.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_project.test", "branches.*", map[string]string{"name": "staging"}),
+				),
+			},
+			// ImportState testing, which leaves the inline sets unset as objects may be managed elsewhere
+			{
+				ResourceName:       "neon_project.test",
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					for _, attribute := range []string{"roles.#", "role_passwords.%", "databases.#", "branches.#"} {
+						if value, ok := states[0].Attributes[attribute]; ok {
+							return fmt.Errorf("expected %s to be unset after import, got %s", attribute, value)
+						}
+					}
+
+					return nil
+				},
+			},
+			// Declaring the sets again adopts the existing objects and leaves the branch of neon_branch alone
+			{
+				Config: testAccProjectResourceConfigInline(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_project.test", "roles.#", "2"),
+					resource.TestCheckResourceAttr("neon_project.test", "databases.#", "1"),
+					resource.TestCheckResourceAttr("neon_project.test", "branches.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_project.test", "branches.*", map[string]string{"name": "staging"}),
+					resource.TestCheckResourceAttr("neon_branch.test", "name", "preview"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccProjectResourceConfigInlineUpdate(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_project.test", "roles.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_project.test", "roles.*", map[string]string{"name": "reader"}),
+					resource.TestCheckResourceAttr("neon_project.test", "role_passwords.%", "1"),
+					resource.TestMatchResourceAttr("neon_project.test", "role_passwords.reader", existRegex()),
+					resource.TestCheckResourceAttr("neon_project.test", "databases.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_project.test", "databases.*", map[string]string{"name": "orders", "owner_name": "reader"}),
+					resource.TestCheckTypeSetElemNestedAttrs("neon_project.test", "databases.*", map[string]string{"name": "events", "owner_name": "reader"}),
+					resource.TestCheckResourceAttr("neon_project.test", "branches.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
 func testAccProjectResourceConfigDefaultForUser(name string) string {
 	return fmt.Sprintf(`
 resource "neon_project" "test" {
@@ -432,6 +638,41 @@ resource "neon_project" "test" {
 `, name)
 }
 
+func testAccProjectResourceConfigPgSettings(name string, workMem string) string {
+	return fmt.Sprintf(`
+resource "neon_project" "test" {
+  name      = "%s"
+  region_id = "aws-us-east-2"
+  org_id    = "org-aged-sky-67916740"
+
+  branch = {
+    endpoint = {
+      pg_settings = {
+        work_mem = "%s"
+      }
+    }
+  }
+}
+`, name, workMem)
+}
+
+func testAccProjectResourceConfigComputeSize(minCu float64, maxCu float64) string {
+	return fmt.Sprintf(`
+resource "neon_project" "test" {
+  name      = "todo-app"
+  region_id = "aws-us-east-2"
+  org_id    = "org-aged-sky-67916740"
+
+  branch = {
+    endpoint = {
+      min_cu = %g
+      max_cu = %g
+    }
+  }
+}
+`, minCu, maxCu)
+}
+
 func testAccProjectResourceConfigNonDefault(name string) string {
 	return fmt.Sprintf(`
 resource "neon_project" "test" {
@@ -488,3 +729,78 @@ resource "neon_project" "test" {
 }
 `, name)
 }
+
+func testAccProjectResourceConfigKeepDefaults(keepDefaultDatabase bool, keepDefaultRole bool) string {
+	return fmt.Sprintf(`
+resource "neon_project" "test" {
+  name = "prototype"
+  region_id = "aws-us-east-2"
+  org_id = "org-aged-sky-67916740"
+
+  keep_default_database = %t
+  keep_default_role = %t
+}
+`, keepDefaultDatabase, keepDefaultRole)
+}
+
+func testAccProjectResourceConfigStorePasswords(storePasswords bool) string {
+	return fmt.Sprintf(`
+resource "neon_project" "test" {
+  name = "passwords"
+  region_id = "aws-us-east-2"
+  org_id = "org-aged-sky-67916740"
+
+  store_passwords = %t
+}
+`, storePasswords)
+}
+
+func testAccProjectResourceConfigInline() string {
+	return `
+resource "neon_project" "test" {
+  name = "inline"
+  region_id = "aws-us-east-2"
+  org_id = "org-aged-sky-67916740"
+
+  roles = [
+    { name = "admin" },
+    { name = "reader" },
+  ]
+
+  databases = [
+    { name = "orders", owner_name = "admin" },
+  ]
+
+  branches = [
+    { name = "staging" },
+  ]
+}
+
+# Branches managed elsewhere are not tracked by the project
+resource "neon_branch" "test" {
+  name       = "preview"
+  project_id = neon_project.test.id
+}
+`
+}
+
+func testAccProjectResourceConfigInlineUpdate() string {
+	return `
+resource "neon_project" "test" {
+  name = "inline"
+  region_id = "aws-us-east-2"
+  org_id = "org-aged-sky-67916740"
+
+  roles = [
+    { name = "reader" },
+  ]
+
+  databases = [
+    { name = "orders", owner_name = "reader" },
+    { name = "events", owner_name = "reader" },
+  ]
+
+  branches = []
+}
+`
+}
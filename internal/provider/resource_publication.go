@@ -0,0 +1,422 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &PublicationResource{}
+var _ resource.ResourceWithImportState = &PublicationResource{}
+
+func NewPublicationResource() resource.Resource {
+	return &PublicationResource{}
+}
+
+type PublicationResource struct {
+	client *http.Client
+}
+
+type PublicationResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	AllTables    types.Bool   `tfsdk:"all_tables"`
+	Tables       types.Set    `tfsdk:"tables"`
+	Publish      types.Set    `tfsdk:"publish"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	RoleName     types.String `tfsdk:"role_name"`
+	BranchId     types.String `tfsdk:"branch_id"`
+	ProjectId    types.String `tfsdk:"project_id"`
+}
+
+func (r *PublicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_publication"
+}
+
+func (r *PublicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Logical replication publication in a Neon database, managed over SQL. The project must store passwords and have `logical_replication` enabled.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the publication.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the publication.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"all_tables": schema.BoolAttribute{
+				MarkdownDescription: "Whether the publication includes all tables of the database, including tables created in the future. **Default** `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"tables": schema.SetAttribute{
+				MarkdownDescription: "Tables included in the publication as `schema.table`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(path.MatchRoot("all_tables")),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^.]+\.[^.]+$`), "must be schema.table"),
+					),
+				},
+			},
+			"publish": schema.SetAttribute{
+				MarkdownDescription: "Operations published. **Options:** `insert`, `update`, `delete`, `truncate`. Defaults to all of them.",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(publicationOperations...)),
+				},
+			},
+			"database_name": schema.StringAttribute{
+				MarkdownDescription: "Database the publication belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Role to connect as. Defaults to the owner of the database.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.UTF8LengthAtLeast(1),
+				},
+			},
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "Branch the database belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project the database belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegex(), "must be an id"),
+				},
+			},
+		},
+	}
+}
+
+func (r *PublicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PublicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *PublicationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = publicationCreate(ctx, conn, publicationFrom(ctx, data))
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create publication, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a publication")
+
+	publication, err := publicationRead(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read publication, got error: %s", err))
+		return
+	}
+
+	if publication == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read publication, %s does not exist", data.Name.ValueString()))
+		return
+	}
+
+	data.Id = types.StringValue(publicationId(data))
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(publicationSetData(ctx, data, publication)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PublicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *PublicationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	publication, err := publicationRead(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read publication, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "read a publication")
+
+	if publication == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(publicationId(data))
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(publicationSetData(ctx, data, publication)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PublicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *PublicationResourceModel
+	var state *PublicationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = publicationUpdate(ctx, conn, publicationFrom(ctx, state), publicationFrom(ctx, data))
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update publication, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated a publication")
+
+	publication, err := publicationRead(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read publication, got error: %s", err))
+		return
+	}
+
+	if publication == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read publication, %s does not exist", data.Name.ValueString()))
+		return
+	}
+
+	data.Id = types.StringValue(publicationId(data))
+	data.RoleName = types.StringValue(conn.Config().User)
+
+	resp.Diagnostics.Append(publicationSetData(ctx, data, publication)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PublicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *PublicationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.DatabaseName.ValueString(), data.RoleName.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+		return
+	}
+
+	defer conn.Close(ctx)
+
+	err = publicationDelete(ctx, conn, data.Name.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete publication, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a publication")
+}
+
+func (r *PublicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id:branch_id:database_name:name. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("branch_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[3])...)
+}
+
+func publicationFrom(ctx context.Context, data *PublicationResourceModel) Publication {
+	publication := Publication{
+		Name:      data.Name.ValueString(),
+		AllTables: data.AllTables.ValueBool(),
+	}
+
+	data.Tables.ElementsAs(ctx, &publication.Tables, false)
+
+	if !data.Publish.IsUnknown() {
+		data.Publish.ElementsAs(ctx, &publication.Publish, false)
+	}
+
+	return publication
+}
+
+func publicationSetData(ctx context.Context, data *PublicationResourceModel, publication *Publication) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.AllTables = types.BoolValue(publication.AllTables)
+	data.Publish, diags = types.SetValueFrom(ctx, types.StringType, publication.Publish)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	// Keep tables null when the publication has none, such as publications for
+	// all tables.
+	if len(publication.Tables) == 0 && data.Tables.IsNull() {
+		return diags
+	}
+
+	tables, tablesDiags := types.SetValueFrom(ctx, types.StringType, publication.Tables)
+
+	diags.Append(tablesDiags...)
+
+	data.Tables = tables
+
+	return diags
+}
+
+func publicationId(data *PublicationResourceModel) string {
+	return strings.Join(
+		[]string{
+			data.ProjectId.ValueString(),
+			data.BranchId.ValueString(),
+			data.DatabaseName.ValueString(),
+			data.Name.ValueString(),
+		},
+		":",
+	)
+}
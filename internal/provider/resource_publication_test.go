@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPublicationResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPublicationResourceConfigDefault(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_publication.test", "id", existRegex()),
+					resource.TestCheckResourceAttr("neon_publication.test", "name", "warehouse"),
+					resource.TestCheckResourceAttr("neon_publication.test", "all_tables", "true"),
+					resource.TestCheckNoResourceAttr("neon_publication.test", "tables"),
+					resource.TestCheckResourceAttr("neon_publication.test", "publish.#", "4"),
+					resource.TestCheckResourceAttr("neon_publication.test", "database_name", "orders"),
+					resource.TestCheckResourceAttr("neon_publication.test", "role_name", "cdc"),
+					resource.TestMatchResourceAttr("neon_publication.test", "branch_id", idRegex()),
+					resource.TestMatchResourceAttr("neon_publication.test", "project_id", idRegex()),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "neon_publication.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccPublicationImportStateId,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPublicationResourceConfigNonDefault(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_publication.test", "name", "warehouse"),
+					resource.TestCheckResourceAttr("neon_publication.test", "all_tables", "false"),
+					resource.TestCheckNoResourceAttr("neon_publication.test", "tables"),
+					resource.TestCheckResourceAttr("neon_publication.test", "publish.#", "2"),
+					resource.TestCheckTypeSetElemAttr("neon_publication.test", "publish.*", "insert"),
+					resource.TestCheckTypeSetElemAttr("neon_publication.test", "publish.*", "update"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPublicationImportStateId(s *terraform.State) (string, error) {
+	publication := s.RootModule().Resources["neon_publication.test"]
+
+	if publication == nil {
+		return "", fmt.Errorf("neon_publication.test not found")
+	}
+
+	return publication.Primary.ID, nil
+}
+
+func testAccPublicationResourceConfig(publication string) string {
+	return fmt.Sprintf(`
+resource "neon_project" "test" {
+  name = "cdc-test"
+  region_id = "aws-us-east-2"
+  org_id = "org-aged-sky-67916740"
+
+  logical_replication = true
+}
+
+resource "neon_role" "test" {
+  name = "cdc"
+  branch_id = neon_project.test.branch.id
+  project_id = neon_project.test.id
+}
+
+resource "neon_database" "test" {
+  name = "orders"
+  owner_name = neon_role.test.name
+  branch_id = neon_project.test.branch.id
+  project_id = neon_project.test.id
+}
+
+resource "neon_publication" "test" {
+  name = "warehouse"
+  database_name = neon_database.test.name
+  branch_id = neon_project.test.branch.id
+  project_id = neon_project.test.id
+%s
+}
+`, publication)
+}
+
+func testAccPublicationResourceConfigDefault() string {
+	return testAccPublicationResourceConfig(`
+  all_tables = true
+`)
+}
+
+func testAccPublicationResourceConfigNonDefault() string {
+	return testAccPublicationResourceConfig(`
+  publish = ["insert", "update"]
+`)
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccReplicationSlotResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccReplicationSlotResourceConfigDefault(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("neon_replication_slot.test", "id", existRegex()),
+					resource.TestCheckResourceAttr("neon_replication_slot.test", "name", "warehouse"),
+					resource.TestCheckResourceAttr("neon_replication_slot.test", "plugin", "pgoutput"),
+					resource.TestCheckResourceAttr("neon_replication_slot.test", "database_name", "orders"),
+					resource.TestCheckResourceAttr("neon_replication_slot.test", "role_name", "cdc"),
+					resource.TestMatchResourceAttr("neon_replication_slot.test", "branch_id", idRegex()),
+					resource.TestMatchResourceAttr("neon_replication_slot.test", "project_id", idRegex()),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccReplicationSlotResourceConfigDefault() string {
+	return `
+resource "neon_project" "test" {
+  name = "cdc-test"
+  region_id = "aws-us-east-2"
+  org_id = "org-aged-sky-67916740"
+
+  logical_replication = true
+}
+
+resource "neon_role" "test" {
+  name = "cdc"
+  branch_id = neon_project.test.branch.id
+  project_id = neon_project.test.id
+}
+
+resource "neon_database" "test" {
+  name = "orders"
+  owner_name = neon_role.test.name
+  branch_id = neon_project.test.branch.id
+  project_id = neon_project.test.id
+}
+
+resource "neon_replication_slot" "test" {
+  name = "warehouse"
+  database_name = neon_database.test.name
+  branch_id = neon_project.test.branch.id
+  project_id = neon_project.test.id
+}
+`
+}
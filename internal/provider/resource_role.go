@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -20,6 +22,38 @@ import (
 var _ resource.Resource = &RoleResource{}
 var _ resource.ResourceWithImportState = &RoleResource{}
 
+func roleIdFromName() planmodifier.String {
+	return roleIdFromNameModifier{}
+}
+
+type roleIdFromNameModifier struct{}
+
+func (m roleIdFromNameModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m roleIdFromNameModifier) MarkdownDescription(_ context.Context) string {
+	return "Follows the name of the role."
+}
+
+func (m roleIdFromNameModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to do on resource destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var name types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The identifier of a role is its name, so it is known as soon as the name is.
+	resp.PlanValue = name
+}
+
 func NewRoleResource() resource.Resource {
 	return &RoleResource{}
 }
@@ -29,12 +63,13 @@ type RoleResource struct {
 }
 
 type RoleResourceModel struct {
-	Id        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Password  types.String `tfsdk:"password"`
-	BranchId  types.String `tfsdk:"branch_id"`
-	ProjectId types.String `tfsdk:"project_id"`
-	Protected types.Bool   `tfsdk:"protected"`
+	Id              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Password        types.String `tfsdk:"password"`
+	BranchId        types.String `tfsdk:"branch_id"`
+	ProjectId       types.String `tfsdk:"project_id"`
+	Protected       types.Bool   `tfsdk:"protected"`
+	PersistPassword types.Bool   `tfsdk:"persist_password"`
 }
 
 func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,27 +78,24 @@ func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataReques
 
 func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Neon role.",
+		MarkdownDescription: "Neon role. Renaming a role keeps its password, privileges and the databases it owns.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Identifier of the role.",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					roleIdFromName(),
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the role.",
+				MarkdownDescription: "Name of the role. The Neon API cannot rename roles, so changing it renames the role over SQL, connected as the owner of a database of the branch not owned by the role.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 				Validators: []validator.String{
 					stringvalidator.UTF8LengthAtLeast(1),
 				},
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password of the role.",
+				MarkdownDescription: "Password of the role. When the project does not store passwords, this is the password from when the role was created and is not set for imported roles.",
 				Computed:            true,
 				Sensitive:           true,
 			},
@@ -91,6 +123,12 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Whether the role is protected.",
 				Computed:            true,
 			},
+			"persist_password": schema.BoolAttribute{
+				MarkdownDescription: "Whether the password is stored in state. Use the `neon_connection_uri` ephemeral resource to get the password when it is not. **Default** `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
 		},
 	}
 }
@@ -148,11 +186,15 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	data.Id = types.StringValue(role.Role.Name)
 	data.Name = types.StringValue(role.Role.Name)
-	data.Password = types.StringValue(role.Role.Password)
+	data.Password = types.StringNull()
 	data.BranchId = types.StringValue(role.Role.BranchId)
 	data.ProjectId = types.StringValue(branch.Branch.ProjectId)
 	data.Protected = types.BoolValue(role.Role.Protected)
 
+	if data.PersistPassword.ValueBool() {
+		data.Password = types.StringValue(role.Role.Password)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -172,8 +214,14 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	project, err := projectGet(r.client, data.ProjectId.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project of the role, got error: %s", err))
+		return
+	}
+
 	var role RoleOutput
-	var rolePassword RolePasswordOutput
 
 	roleUrl := fmt.Sprintf("/projects/%s/branches/%s/roles/%s", data.ProjectId.ValueString(), data.BranchId.ValueString(), data.Name.ValueString())
 
@@ -184,18 +232,31 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	err = get(r.client, fmt.Sprintf("%s/reveal_password", roleUrl), &rolePassword)
+	if data.PersistPassword.IsNull() {
+		data.PersistPassword = types.BoolValue(true)
+	}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role password, got error: %s", err))
-		return
+	// Passwords can only be revealed when the project stores them, otherwise
+	// the password from when the role was created is kept in state
+	if !data.PersistPassword.ValueBool() {
+		data.Password = types.StringNull()
+	} else if project.Project.StorePasswords {
+		var rolePassword RolePasswordOutput
+
+		err = get(r.client, fmt.Sprintf("%s/reveal_password", roleUrl), &rolePassword)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role password, got error: %s", err))
+			return
+		}
+
+		data.Password = types.StringValue(rolePassword.Password)
 	}
 
 	tflog.Trace(ctx, "read a role")
 
 	data.Id = types.StringValue(role.Role.Name)
 	data.Name = types.StringValue(role.Role.Name)
-	data.Password = types.StringValue(rolePassword.Password)
 	data.BranchId = types.StringValue(role.Role.BranchId)
 	data.ProjectId = types.StringValue(branch.Branch.ProjectId)
 	data.Protected = types.BoolValue(role.Role.Protected)
@@ -204,14 +265,79 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RoleResourceModel
 	var state *RoleResourceModel
 
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if !data.Name.Equal(state.Name) {
+		databases, err := databaseList(r.client, data.ProjectId.ValueString(), data.BranchId.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read databases of the branch, got error: %s", err))
+			return
+		}
+
+		// A role cannot rename itself, so connect as the owner of a database the role does not own
+		databaseIdx := slices.IndexFunc(databases.Databases, func(database Database) bool {
+			return database.OwnerName != state.Name.ValueString()
+		})
+
+		if databaseIdx == -1 {
+			resp.Diagnostics.AddError(
+				"Role Rename Not Possible",
+				fmt.Sprintf("Renaming role %s needs a database on branch %s owned by another role to connect with.", state.Name.ValueString(), data.BranchId.ValueString()),
+			)
+
+			return
+		}
+
+		conn, err := sqlConnect(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), databases.Databases[databaseIdx].Name, "")
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to connect to database, got error: %s", err))
+			return
+		}
+
+		defer conn.Close(ctx)
+
+		err = roleRename(ctx, conn, state.Name.ValueString(), data.Name.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename role, got error: %s", err))
+			return
+		}
+
+		tflog.Trace(ctx, "renamed a role")
+
+		role, err := roleWaitVisible(ctx, r.client, data.ProjectId.ValueString(), data.BranchId.ValueString(), data.Name.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read renamed role, got error: %s", err))
+			return
+		}
+
+		state.Id = types.StringValue(role.Role.Name)
+		state.Name = types.StringValue(role.Role.Name)
+		state.Protected = types.BoolValue(role.Role.Protected)
+	}
+
+	state.PersistPassword = data.PersistPassword
+
+	if !state.PersistPassword.ValueBool() {
+		state.Password = types.StringNull()
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
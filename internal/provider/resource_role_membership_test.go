@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoleMembershipResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoleMembershipResourceConfigDefault(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role_membership.test", "id", "polished-snowflake-328957:br-patient-mode-718259:app_ro:app_rw"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "group_role", "app_ro"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "member_role", "app_rw"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "inherit", "true"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "admin_option", "false"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "database_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "role_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "branch_id", "br-patient-mode-718259"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "project_id", "polished-snowflake-328957"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoleMembershipResourceConfigDefault(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role_membership.test", "id", "polished-snowflake-328957:br-patient-mode-718259:app_ro:app_rw"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "inherit", "true"),
+					resource.TestCheckResourceAttr("neon_role_membership.test", "admin_option", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoleMembershipResourceConfigDefault(adminOption bool) string {
+	return fmt.Sprintf(`
+resource "neon_role" "ro" {
+  name = "app_ro"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_role" "rw" {
+  name = "app_rw"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_role_membership" "test" {
+  group_role = neon_role.ro.name
+  member_role = neon_role.rw.name
+  admin_option = %t
+  database_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+`, adminOption)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRolePasswordResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRolePasswordResourceConfigDefault("2024-01"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role_password.test", "role_name", "sally"),
+					resource.TestCheckResourceAttr("neon_role_password.test", "branch_id", "br-patient-mode-718259"),
+					resource.TestCheckResourceAttr("neon_role_password.test", "project_id", "polished-snowflake-328957"),
+					resource.TestCheckResourceAttr("neon_role_password.test", "keepers.rotation", "2024-01"),
+					resource.TestMatchResourceAttr("neon_role_password.test", "password", existRegex()),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRolePasswordResourceConfigDefault("2024-02"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role_password.test", "role_name", "sally"),
+					resource.TestCheckResourceAttr("neon_role_password.test", "keepers.rotation", "2024-02"),
+					resource.TestMatchResourceAttr("neon_role_password.test", "password", existRegex()),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRolePasswordResourceConfigDefault(rotation string) string {
+	return fmt.Sprintf(`
+resource "neon_role" "test" {
+  name = "sally"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_role_password" "test" {
+  role_name = neon_role.test.name
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+
+  keepers = {
+    rotation = "%s"
+  }
+}
+`, rotation)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func existRegex() *regexp.Regexp {
@@ -26,6 +27,7 @@ func TestAccRoleResourceDefault(t *testing.T) {
 					resource.TestCheckResourceAttr("neon_role.test", "branch_id", "br-patient-mode-718259"),
 					resource.TestCheckResourceAttr("neon_role.test", "project_id", "polished-snowflake-328957"),
 					resource.TestCheckResourceAttr("neon_role.test", "protected", "false"),
+					resource.TestCheckResourceAttr("neon_role.test", "persist_password", "true"),
 				),
 			},
 			// ImportState testing
@@ -58,6 +60,93 @@ func TestAccRoleResourceDefault(t *testing.T) {
 	})
 }
 
+func TestAccRoleResourceWithoutStoredPasswords(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoleResourceConfigWithoutStoredPasswords("sally"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_project.test", "store_passwords", "false"),
+					resource.TestCheckResourceAttr("neon_role.test", "name", "sally"),
+					resource.TestMatchResourceAttr("neon_role.test", "password", existRegex()),
+				),
+			},
+			// Update with null values
+			{
+				Config: testAccRoleResourceConfigWithoutStoredPasswords("sally"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "name", "sally"),
+					resource.TestMatchResourceAttr("neon_role.test", "password", existRegex()),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRoleResourceWithoutPersistedPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoleResourceConfigPersistPassword("sally", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "name", "sally"),
+					resource.TestCheckResourceAttr("neon_role.test", "persist_password", "false"),
+					resource.TestCheckNoResourceAttr("neon_role.test", "password"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoleResourceConfigPersistPassword("sally", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "name", "sally"),
+					resource.TestCheckResourceAttr("neon_role.test", "persist_password", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRoleResourceRename(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoleResourceConfigRename("sally"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "name", "sally"),
+					resource.TestCheckResourceAttr("neon_database.test", "owner_name", "sally"),
+				),
+			},
+			// Rename testing
+			{
+				Config: testAccRoleResourceConfigRename("sarah"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("neon_role.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("neon_database.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_role.test", "id", "sarah"),
+					resource.TestCheckResourceAttr("neon_role.test", "name", "sarah"),
+					resource.TestCheckResourceAttr("neon_database.test", "owner_name", "sarah"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
 func testAccRoleResourceConfigDefault(name string) string {
 	return fmt.Sprintf(`
 resource "neon_role" "test" {
@@ -67,3 +156,48 @@ resource "neon_role" "test" {
 }
 `, name)
 }
+
+func testAccRoleResourceConfigWithoutStoredPasswords(name string) string {
+	return fmt.Sprintf(`
+resource "neon_project" "test" {
+  name            = "no-passwords"
+  region_id       = "aws-us-east-2"
+  org_id          = "org-aged-sky-67916740"
+  store_passwords = false
+}
+
+resource "neon_role" "test" {
+  name = "%s"
+  branch_id = neon_project.test.branch.id
+  project_id = neon_project.test.id
+}
+`, name)
+}
+
+func testAccRoleResourceConfigPersistPassword(name string, persistPassword bool) string {
+	return fmt.Sprintf(`
+resource "neon_role" "test" {
+  name = "%s"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+  persist_password = %t
+}
+`, name, persistPassword)
+}
+
+func testAccRoleResourceConfigRename(name string) string {
+	return fmt.Sprintf(`
+resource "neon_role" "test" {
+  name = "%s"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+
+resource "neon_database" "test" {
+  name = "ledger"
+  owner_name = neon_role.test.name
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+`, name)
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSchemaResourceDefault(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSchemaResourceConfigDefault("tenant_acme"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_schema.test", "id", "polished-snowflake-328957:br-patient-mode-718259:budget-app:tenant_acme"),
+					resource.TestCheckResourceAttr("neon_schema.test", "name", "tenant_acme"),
+					resource.TestCheckResourceAttr("neon_schema.test", "owner", "budget-app"),
+					resource.TestCheckResourceAttr("neon_schema.test", "if_not_exists", "false"),
+					resource.TestCheckResourceAttr("neon_schema.test", "drop_cascade", "true"),
+					resource.TestCheckResourceAttr("neon_schema.test", "database_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_schema.test", "role_name", "budget-app"),
+					resource.TestCheckResourceAttr("neon_schema.test", "branch_id", "br-patient-mode-718259"),
+					resource.TestCheckResourceAttr("neon_schema.test", "project_id", "polished-snowflake-328957"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "neon_schema.test",
+				ImportState:             true,
+				ImportStateId:           "polished-snowflake-328957:br-patient-mode-718259:budget-app:tenant_acme",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"drop_cascade"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccSchemaResourceConfigDefault("tenant_globex"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("neon_schema.test", "id", "polished-snowflake-328957:br-patient-mode-718259:budget-app:tenant_globex"),
+					resource.TestCheckResourceAttr("neon_schema.test", "name", "tenant_globex"),
+					resource.TestCheckResourceAttr("neon_schema.test", "owner", "budget-app"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSchemaResourceConfigDefault(name string) string {
+	return fmt.Sprintf(`
+resource "neon_schema" "test" {
+  name = "%s"
+  drop_cascade = true
+  database_name = "budget-app"
+  branch_id = "br-patient-mode-718259"
+  project_id = "polished-snowflake-328957"
+}
+`, name)
+}